@@ -26,11 +26,17 @@ type EventHandler struct {
 type FightMeta struct {
 	ThreadId   string
 	Tournament *TournamentData
+	//Set for world boss fights, loot is split by damage dealt
+	WorldBoss *uuid.UUID
+	//Set for duels, no loot and no death penalty
+	Duel *uuid.UUID
 }
 
 type TournamentData struct {
 	Tournament uuid.UUID
 	Location   string
+	//Participant fighting on each side, a player or a team
+	Sides [2]uuid.UUID
 }
 
 type EntityMap map[uuid.UUID]*EntityEntry
@@ -270,6 +270,34 @@ func (m *MobEntity) GetTempSkills() []*types.WithExpire[types.PlayerSkill] {
 	return m.TempSkill
 }
 
+// Increases stats and exp/gold loot by percent, used for level scaled encounters
+func (m *MobEntity) Scale(percent int) {
+	if percent <= 0 {
+		return
+	}
+
+	stats := make(map[types.Stat]int)
+
+	for stat, value := range m.Stats {
+		stats[stat] = value + utils.PercentOf(value, percent)
+	}
+
+	m.Stats = stats
+	m.HP = m.Stats[types.STAT_HP]
+
+	loot := make([]types.Loot, 0)
+
+	for _, entry := range m.Loot {
+		if entry.Type != types.LOOT_ITEM {
+			entry.Count += utils.PercentOf(entry.Count, percent)
+		}
+
+		loot = append(loot, entry)
+	}
+
+	m.Loot = loot
+}
+
 func Spawn(id string) *MobEntity {
 	temp, ok := Mobs[id]
 
@@ -281,3 +309,29 @@ func Spawn(id string) *MobEntity {
 
 	return &temp
 }
+
+func SpawnEncounter(encounter types.Encounter, level int) []*MobEntity {
+	spawned := make([]*MobEntity, 0)
+
+	scaling := 0
+
+	if level > encounter.Level {
+		scaling = (level - encounter.Level) * encounter.Scaling
+	}
+
+	for _, enemy := range encounter.Mobs {
+		for range utils.RandomNumber(enemy.MinNum, enemy.MaxNum) {
+			entity := Spawn(enemy.Enemy)
+
+			if entity == nil {
+				continue
+			}
+
+			entity.Scale(scaling)
+
+			spawned = append(spawned, entity)
+		}
+	}
+
+	return spawned
+}
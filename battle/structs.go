@@ -25,6 +25,10 @@ type Fight struct {
 	Meta            *FightMeta
 	PlayerActions   chan types.Action
 	EventHandlers   map[uuid.UUID]EventHandler
+	//Summon damage counts towards the owner
+	DamageDealt map[uuid.UUID]int
+	//Player the fight is waiting on, uuid.Nil between turns
+	AwaitingAction uuid.UUID
 }
 
 func (f *Fight) Init() {
@@ -33,6 +37,7 @@ func (f *Fight) Init() {
 	f.ExpireMap = make(map[uuid.UUID]int)
 	f.SummonMap = make(map[uuid.UUID]SummonEntityMeta)
 	f.EventHandlers = make(map[uuid.UUID]EventHandler)
+	f.DamageDealt = make(map[uuid.UUID]int)
 }
 
 func (f *Fight) SidesLeft() []int {
@@ -97,6 +102,14 @@ func (f *Fight) TriggerEvent(source types.Entity, target types.Entity, event typ
 	return returnValue
 }
 
+func (f *Fight) RecordDamage(source uuid.UUID, value int) {
+	if summon, isSummon := f.SummonMap[source]; isSummon {
+		source = summon.Owner
+	}
+
+	f.DamageDealt[source] += value
+}
+
 func (f *Fight) GetChannelId() string {
 	if f.Meta.ThreadId != "" {
 		return f.Meta.ThreadId
@@ -495,6 +508,8 @@ func (f *Fight) TriggerAttackEffect(dodged bool, damage map[types.DamageType]int
 	if !dodged {
 		dmgSum := damage[0] + damage[1] + damage[2]
 
+		f.RecordDamage(meta.Source.GetUUID(), dmgSum)
+
 		vampType := types.STAT_ATK_VAMP
 
 		if meta.IsSkill {
@@ -801,6 +816,8 @@ func (f *Fight) RequestAction(uid uuid.UUID) {
 
 		record.Entity.(types.PlayerEntity).ReduceCooldowns(types.TRIGGER_TURN)
 
+		f.AwaitingAction = uid
+
 		f.ExternalChannel <- FightActionNeededMsg{Entity: uid}
 
 		tempAction := <-f.PlayerActions
@@ -814,6 +831,8 @@ func (f *Fight) RequestAction(uid uuid.UUID) {
 
 			f.HandleAction(tempAction)
 		}
+
+		f.AwaitingAction = uuid.Nil
 	} else {
 		if record.Entity.GetEffectByType(types.EFFECT_STUN) == nil {
 			for _, action := range record.Entity.Action(f) {
@@ -879,6 +898,17 @@ func (f *Fight) GetTurnFor(uuid uuid.UUID) int {
 	return f.Entities[uuid].Turn
 }
 
+// Knocks out the whole side, pending player turn is skipped so the fight can end
+func (f *Fight) Forfeit(side int) {
+	for _, entity := range f.FromSide(side) {
+		entity.ChangeHP(-entity.GetCurrentHP())
+	}
+
+	if f.AwaitingAction != uuid.Nil {
+		f.PlayerActions <- types.Action{Event: types.ACTION_SKIP, Source: f.AwaitingAction}
+	}
+}
+
 func (f *Fight) IsFinished() bool {
 	return len(f.SidesLeft()) <= 1
 }
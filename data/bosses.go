@@ -0,0 +1,90 @@
+package data
+
+import (
+	"os"
+	saoParts "sao/parts"
+	"sao/types"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/tfo-dot/parts"
+)
+
+var WorldBosses = GetWorldBosses()
+
+func GetWorldBosses() map[uuid.UUID]types.WorldBoss {
+	dirData, err := os.ReadDir(Config.GameDataLocation + "/bosses")
+
+	if err != nil {
+		panic(err)
+	}
+
+	bosses := map[uuid.UUID]types.WorldBoss{}
+
+	for _, file := range dirData {
+		if file.IsDir() {
+			continue
+		}
+
+		if !strings.HasSuffix(file.Name(), ".pts") {
+			continue
+		}
+
+		println("Loading world boss: " + file.Name())
+
+		code, err := os.ReadFile(Config.GameDataLocation + "/bosses/" + file.Name())
+
+		if err != nil {
+			panic(err)
+		}
+
+		vm, err := parts.GetVMWithSource(string(code))
+
+		if err != nil {
+			panic(err)
+		}
+
+		saoParts.AddConsts(vm)
+		saoParts.AddFunctions(vm)
+
+		err = vm.Run()
+
+		if err != nil {
+			panic(err)
+		}
+
+		boss := types.WorldBoss{Loot: make([]types.Loot, 0)}
+
+		parts.ReadFromParts(vm, &boss)
+
+		rawUUID, err := saoParts.FetchVal(vm, "UUID")
+
+		if err != nil {
+			panic(err)
+		}
+
+		boss.UUID = uuid.MustParse(rawUUID.(string))
+
+		if FloorMap.GetLocation(types.EntityLocation{Floor: boss.Floor, Location: boss.Location}) == nil {
+			panic("Unknown location in world boss: " + file.Name())
+		}
+
+		if boss.Interval <= 0 || boss.JoinWindow <= 0 || boss.Count <= 0 {
+			panic("Invalid schedule in world boss: " + file.Name())
+		}
+
+		for _, loot := range boss.Loot {
+			if loot.Type != types.LOOT_ITEM {
+				continue
+			}
+
+			if _, exists := Items[uuid.MustParse(loot.Item)]; !exists {
+				panic("Unknown loot item in world boss: " + file.Name())
+			}
+		}
+
+		bosses[boss.UUID] = boss
+	}
+
+	return bosses
+}
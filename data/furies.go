@@ -0,0 +1,272 @@
+package data
+
+import (
+	"fmt"
+	"os"
+	saoParts "sao/parts"
+	"sao/types"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/tfo-dot/parts"
+)
+
+var Furies = GetFuries()
+
+func GetFuries() map[uuid.UUID]types.FuryDefinition {
+	dirData, err := os.ReadDir(Config.GameDataLocation + "/furies")
+
+	if err != nil {
+		panic(err)
+	}
+
+	furies := map[uuid.UUID]types.FuryDefinition{}
+
+	for _, file := range dirData {
+		if file.IsDir() {
+			continue
+		}
+
+		if !strings.HasSuffix(file.Name(), ".pts") {
+			continue
+		}
+
+		println("Loading fury: " + file.Name())
+
+		code, err := os.ReadFile(Config.GameDataLocation + "/furies/" + file.Name())
+
+		if err != nil {
+			panic(err)
+		}
+
+		vm, err := parts.GetVMWithSource(string(code))
+
+		if err != nil {
+			panic(err)
+		}
+
+		saoParts.AddConsts(vm)
+		saoParts.AddFunctions(vm)
+
+		err = vm.Run()
+
+		if err != nil {
+			panic(err)
+		}
+
+		fury := types.FuryDefinition{Tiers: make([]types.FuryTier, 0)}
+
+		parts.ReadFromParts(vm, &fury)
+
+		rawUUID, err := saoParts.FetchVal(vm, "UUID")
+
+		if err != nil {
+			panic(err)
+		}
+
+		fury.UUID = uuid.MustParse(rawUUID.(string))
+
+		rawTiers, err := saoParts.FetchVal(vm, "Tiers")
+
+		if err != nil {
+			panic(err)
+		}
+
+		for _, rawTier := range rawTiers.([]any) {
+			tierData := rawTier.(map[string]any)
+
+			tier := types.FuryTier{
+				Stats:        make(map[types.Stat]int),
+				Skills:       make([]types.PlayerSkill, 0),
+				Requirements: make([]types.FuryRequirement, 0),
+			}
+
+			if val, has := tierData["RTStats"]; has {
+				tier.Stats = parseFuryStats(vm, val.(map[string]any))
+			}
+
+			if val, has := tierData["RTSkills"]; has {
+				for _, skill := range val.([]any) {
+					tier.Skills = append(tier.Skills, NewFurySkill(skill.(map[string]any)))
+				}
+			}
+
+			if val, has := tierData["RTRequirements"]; has {
+				for _, requirement := range val.([]any) {
+					reqData := requirement.(map[string]any)
+
+					parsed := types.FuryRequirement{
+						Type:  types.FuryRequirementType(reqData["RTType"].(int)),
+						Count: reqData["RTCount"].(int),
+					}
+
+					if target, has := reqData["RTTarget"]; has {
+						parsed.Target = target.(string)
+					}
+
+					tier.Requirements = append(tier.Requirements, parsed)
+				}
+			}
+
+			fury.Tiers = append(fury.Tiers, tier)
+		}
+
+		if len(fury.Tiers) == 0 {
+			panic("Fury without tiers: " + file.Name())
+		}
+
+		for _, tier := range fury.Tiers {
+			for _, requirement := range tier.Requirements {
+				switch requirement.Type {
+				case types.FURY_REQ_ITEM:
+					if _, exists := Items[uuid.MustParse(requirement.Target)]; !exists {
+						panic("Unknown required item in fury: " + file.Name())
+					}
+				case types.FURY_REQ_KILL:
+					if requirement.Target == "" {
+						panic("Kill requirement without mob in fury: " + file.Name())
+					}
+				}
+			}
+		}
+
+		rawLvlStats, err := saoParts.FetchVal(vm, "LvlStats")
+
+		if err != nil {
+			panic(err)
+		}
+
+		lvlStatsFunc := rawLvlStats.(func(...any) (any, error))
+
+		fury.LvlStats = func(lvl int, tier int) map[types.Stat]int {
+			res, err := lvlStatsFunc(lvl, tier)
+
+			if err != nil {
+				panic(err)
+			}
+
+			return parseFuryStats(vm, res.(map[string]any))
+		}
+
+		//Checked once on load so broken curves don't surface mid fight
+		fury.LvlStats(1, 1)
+
+		furies[fury.UUID] = fury
+	}
+
+	for _, item := range Items {
+		if item.Fury == "" {
+			continue
+		}
+
+		if _, exists := furies[uuid.MustParse(item.Fury)]; !exists {
+			panic("Unknown fury in item: " + item.Name)
+		}
+	}
+
+	return furies
+}
+
+func parseFuryStats(vm *parts.VM, rawStats map[string]any) map[types.Stat]int {
+	stats := make(map[types.Stat]int)
+
+	for key, value := range rawStats {
+		keyRaw, err := vm.Enviroment.Resolve(fmt.Sprintf("STAT_%s", strings.TrimPrefix(key, "RT")))
+
+		if err != nil {
+			panic(err)
+		}
+
+		stats[types.Stat(keyRaw.Value.(int))] = value.(int)
+	}
+
+	return stats
+}
+
+type FurySkill struct {
+	SkillData map[string]any
+	Uuid      uuid.UUID
+}
+
+func NewFurySkill(skillData map[string]any) FurySkill {
+	skillUuid := uuid.New()
+
+	if rawUuid, has := skillData["RTUUID"]; has {
+		skillUuid = uuid.MustParse(rawUuid.(string))
+	}
+
+	return FurySkill{skillData, skillUuid}
+}
+
+func (fs FurySkill) Execute(owner types.PlayerEntity, target types.Entity, fightInstance types.FightInstance, meta any) any {
+	if execute, exists := fs.SkillData["RTExecute"]; exists {
+		res, err := execute.(func(...any) (any, error))(owner, target, fightInstance, meta)
+
+		if err != nil {
+			panic(err)
+		}
+
+		return res
+	}
+
+	return nil
+}
+
+func (fs FurySkill) GetEvents() map[types.CustomTrigger]func(owner types.PlayerEntity) {
+	return nil
+}
+
+func (fs FurySkill) GetUUID() uuid.UUID {
+	return fs.Uuid
+}
+
+func (fs FurySkill) GetName() string {
+	if name, exists := fs.SkillData["RTName"]; exists {
+		return name.(string)
+	}
+
+	return ""
+}
+
+func (fs FurySkill) GetDescription() string {
+	if description, exists := fs.SkillData["RTDescription"]; exists {
+		return description.(string)
+	}
+
+	return ""
+}
+
+func (fs FurySkill) GetCD() int {
+	if cd, exists := fs.SkillData["RTCD"]; exists {
+		return cd.(int)
+	}
+
+	return 0
+}
+
+func (fs FurySkill) GetCost() int {
+	if cost, exists := fs.SkillData["RTCost"]; exists {
+		return cost.(int)
+	}
+
+	return 0
+}
+
+func (fs FurySkill) GetTrigger() types.Trigger {
+	triggerData, exists := fs.SkillData["RTTrigger"]
+
+	if !exists {
+		return types.Trigger{Type: types.TRIGGER_TYPE_NONE}
+	}
+
+	trigger := triggerData.(map[string]any)
+
+	return types.Trigger{
+		Type:  types.SkillTriggerType(trigger["RTType"].(int)),
+		Event: types.SkillTrigger(trigger["RTEvent"].(int)),
+	}
+}
+
+func (fs FurySkill) IsLevelSkill() bool {
+	return false
+}
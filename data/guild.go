@@ -0,0 +1,104 @@
+package data
+
+import (
+	"os"
+	saoParts "sao/parts"
+	"sao/types"
+
+	"github.com/tfo-dot/parts"
+)
+
+var GuildConfig = GetGuildConfig()
+
+type GuildConfigStruct struct {
+	CreateCost int
+	//Percent of members' fight exp
+	ActivityXP int
+	//In minutes
+	InviteTime int
+	Levels     []types.GuildLevel
+}
+
+func GetGuildConfig() GuildConfigStruct {
+	println("Loading guild config:", Config.GameDataLocation+"/guild/config.pts")
+
+	code, err := os.ReadFile(Config.GameDataLocation + "/guild/config.pts")
+
+	if err != nil {
+		panic(err)
+	}
+
+	vm, err := parts.GetVMWithSource(string(code))
+
+	if err != nil {
+		panic(err)
+	}
+
+	saoParts.AddConsts(vm)
+	saoParts.AddFunctions(vm)
+
+	err = vm.Run()
+
+	if err != nil {
+		panic(err)
+	}
+
+	config := GuildConfigStruct{Levels: make([]types.GuildLevel, 0)}
+
+	for key, target := range map[string]*int{
+		"CREATE_COST": &config.CreateCost,
+		"ACTIVITY_XP": &config.ActivityXP,
+		"INVITE_TIME": &config.InviteTime,
+	} {
+		rawVal, err := saoParts.FetchVal(vm, key)
+
+		if err != nil {
+			panic(err)
+		}
+
+		*target = rawVal.(int)
+	}
+
+	rawLevels, err := saoParts.FetchVal(vm, "Levels")
+
+	if err != nil {
+		panic(err)
+	}
+
+	for _, rawLevel := range rawLevels.([]any) {
+		levelData := rawLevel.(map[string]any)
+
+		level := types.GuildLevel{
+			XP:         levelData["RTXP"].(int),
+			MaxMembers: levelData["RTMaxMembers"].(int),
+			Bonuses:    make([]types.GuildBonus, 0),
+		}
+
+		for _, rawBonus := range levelData["RTBonuses"].([]any) {
+			bonusData := rawBonus.(map[string]any)
+
+			bonus := types.GuildBonus{
+				Stat:  types.Stat(bonusData["RTStat"].(int)),
+				Value: bonusData["RTValue"].(int),
+			}
+
+			if val, has := bonusData["RTPercent"]; has {
+				bonus.Percent = val.(bool)
+			}
+
+			level.Bonuses = append(level.Bonuses, bonus)
+		}
+
+		if len(config.Levels) > 0 && level.XP <= config.Levels[len(config.Levels)-1].XP {
+			panic("Guild levels have to require increasing exp")
+		}
+
+		config.Levels = append(config.Levels, level)
+	}
+
+	if len(config.Levels) == 0 {
+		panic("Guild config without levels")
+	}
+
+	return config
+}
@@ -81,19 +81,106 @@ func GetFloors() Floors {
 				}
 			}
 
+			flags := make([]string, 0)
+
+			if val, has := locData["RTFlags"]; has {
+				for _, flag := range val.([]any) {
+					flags = append(flags, flag.(string))
+				}
+			}
+
+			encounters := make([]types.Encounter, 0)
+
+			if val, has := locData["RTEncounters"]; has {
+				for _, encounter := range val.([]any) {
+					encounterData := encounter.(map[string]any)
+
+					encounterMobs := make([]types.EnemyMeta, 0)
+
+					for _, mob := range encounterData["RTMobs"].([]any) {
+						mobData := mob.(map[string]any)
+
+						encounterMobs = append(encounterMobs, types.EnemyMeta{
+							MinNum: mobData["RTMinNum"].(int),
+							MaxNum: mobData["RTMaxNum"].(int),
+							Enemy:  mobData["RTEnemy"].(string),
+						})
+					}
+
+					parsed := types.Encounter{Weight: encounterData["RTWeight"].(int), Mobs: encounterMobs}
+
+					if parsed.Weight <= 0 {
+						panic("Encounter without positive weight in location: " + locData["RTName"].(string))
+					}
+
+					if rare, has := encounterData["RTRare"]; has {
+						parsed.Rare = rare.(bool)
+					}
+
+					if level, has := encounterData["RTLevel"]; has {
+						parsed.Level = level.(int)
+					}
+
+					if scaling, has := encounterData["RTScaling"]; has {
+						parsed.Scaling = scaling.(int)
+					}
+
+					encounters = append(encounters, parsed)
+				}
+			}
+
+			connections := make([]types.LocationConnection, 0)
+
+			if val, has := locData["RTConnections"]; has {
+				for _, connection := range val.([]any) {
+					connectionData := connection.(map[string]any)
+
+					connections = append(connections, types.LocationConnection{
+						Floor:    connectionData["RTFloor"].(string),
+						Location: connectionData["RTLocation"].(string),
+						Time:     connectionData["RTTime"].(int),
+					})
+				}
+			}
+
 			floorInfo.Locations = append(floorInfo.Locations, types.Location{
-				Name:     locData["RTName"].(string),
-				CID:      locData["RTCID"].(string),
-				CityPart: locData["RTCityPart"].(bool),
-				TP:       locData["RTTP"].(bool),
-				Unlocked: locData["RTUnlocked"].(bool),
-				Enemies:  mobs,
+				Name:        locData["RTName"].(string),
+				CID:         locData["RTCID"].(string),
+				CityPart:    locData["RTCityPart"].(bool),
+				TP:          locData["RTTP"].(bool),
+				Unlocked:    locData["RTUnlocked"].(bool),
+				Enemies:     mobs,
+				Flags:       flags,
+				Connections: connections,
+				Encounters:  encounters,
 			})
 		}
 
 		floors[floorInfo.Name] = floorInfo
 	}
 
+	for _, floor := range floors {
+		for _, loc := range floor.Locations {
+			for _, connection := range loc.Connections {
+				if _, exists := floors[connection.Floor]; !exists || floors[connection.Floor].FindLocation(connection.Location) == nil {
+					panic("Unknown connected location in floor: " + floor.Name)
+				}
+			}
+		}
+
+		if floor.Next == "" {
+			continue
+		}
+
+		if _, exists := floors[floor.Next]; !exists {
+			panic("Unknown next floor in floor: " + floor.Name)
+		}
+
+		if floor.Boss == "" {
+			panic("Floor with next floor but without boss: " + floor.Name)
+		}
+	}
+
 	return floors
 }
 
@@ -109,6 +196,40 @@ func (f Floors) FindLocation(check func(types.Location) bool) *types.Location {
 	return nil
 }
 
+func (f Floors) FindFloor(check func(types.Location) bool) *types.Floor {
+	for _, flor := range f {
+		for _, loc := range flor.Locations {
+			if check(loc) {
+				return &flor
+			}
+		}
+	}
+
+	return nil
+}
+
+func (f Floors) GetLocation(location types.EntityLocation) *types.Location {
+	floor, exists := f[location.Floor]
+
+	if !exists {
+		return nil
+	}
+
+	return floor.FindLocation(location.Location)
+}
+
+func (f Floors) UnlockFloor(name string) {
+	floor, exists := f[name]
+
+	if !exists {
+		return
+	}
+
+	floor.Unlocked = true
+
+	f[name] = floor
+}
+
 func (f Floors) GetUnlockedFloorCount() int {
 	unlockedFloors := 0
 
@@ -0,0 +1,161 @@
+package data
+
+import (
+	"os"
+	saoParts "sao/parts"
+	"sao/types"
+
+	"github.com/tfo-dot/parts"
+)
+
+var PartyConfig = GetPartyConfig()
+
+type PartyConfigStruct struct {
+	//Keyed by role id, kept in the order from the file for listings
+	Roles     map[string]types.PartyRoleDefinition
+	RoleOrder []string
+	Synergies []types.PartySynergy
+	MaxSize   int
+	//In minutes
+	InviteTime int
+}
+
+func GetPartyConfig() PartyConfigStruct {
+	println("Loading party config:", Config.GameDataLocation+"/party/config.pts")
+
+	code, err := os.ReadFile(Config.GameDataLocation + "/party/config.pts")
+
+	if err != nil {
+		panic(err)
+	}
+
+	vm, err := parts.GetVMWithSource(string(code))
+
+	if err != nil {
+		panic(err)
+	}
+
+	saoParts.AddConsts(vm)
+	saoParts.AddFunctions(vm)
+
+	err = vm.Run()
+
+	if err != nil {
+		panic(err)
+	}
+
+	config := PartyConfigStruct{
+		Roles:     make(map[string]types.PartyRoleDefinition),
+		RoleOrder: make([]string, 0),
+		Synergies: make([]types.PartySynergy, 0),
+	}
+
+	rawMaxSize, err := saoParts.FetchVal(vm, "MAX_SIZE")
+
+	if err != nil {
+		panic(err)
+	}
+
+	config.MaxSize = rawMaxSize.(int)
+
+	rawInviteTime, err := saoParts.FetchVal(vm, "INVITE_TIME")
+
+	if err != nil {
+		panic(err)
+	}
+
+	config.InviteTime = rawInviteTime.(int)
+
+	rawRoles, err := saoParts.FetchVal(vm, "Roles")
+
+	if err != nil {
+		panic(err)
+	}
+
+	for _, rawRole := range rawRoles.([]any) {
+		roleData := rawRole.(map[string]any)
+
+		role := types.PartyRoleDefinition{
+			Id:      roleData["RTId"].(string),
+			Name:    roleData["RTName"].(string),
+			Bonuses: parsePartyBonuses(roleData["RTBonuses"]),
+		}
+
+		if val, has := roleData["RTMinLevel"]; has {
+			role.MinLevel = val.(int)
+		}
+
+		if val, has := roleData["RTPath"]; has {
+			path := types.SkillPath(val.(int))
+			role.Path = &path
+		}
+
+		if val, has := roleData["RTTauntMembers"]; has {
+			role.TauntMembers = val.(int)
+		}
+
+		if _, exists := config.Roles[role.Id]; exists {
+			panic("Duplicate party role: " + role.Id)
+		}
+
+		config.Roles[role.Id] = role
+		config.RoleOrder = append(config.RoleOrder, role.Id)
+	}
+
+	rawSynergies, err := saoParts.FetchVal(vm, "Synergies")
+
+	if err != nil {
+		panic(err)
+	}
+
+	for _, rawSynergy := range rawSynergies.([]any) {
+		synergyData := rawSynergy.(map[string]any)
+
+		synergy := types.PartySynergy{
+			Name:    synergyData["RTName"].(string),
+			Roles:   make([]string, 0),
+			Bonuses: parsePartyBonuses(synergyData["RTBonuses"]),
+		}
+
+		for _, role := range synergyData["RTRoles"].([]any) {
+			if _, exists := config.Roles[role.(string)]; !exists {
+				panic("Unknown role in party synergy: " + synergy.Name)
+			}
+
+			synergy.Roles = append(synergy.Roles, role.(string))
+		}
+
+		config.Synergies = append(config.Synergies, synergy)
+	}
+
+	return config
+}
+
+func parsePartyBonuses(rawBonuses any) []types.PartyBonus {
+	bonuses := make([]types.PartyBonus, 0)
+
+	if rawBonuses == nil {
+		return bonuses
+	}
+
+	for _, rawBonus := range rawBonuses.([]any) {
+		bonusData := rawBonus.(map[string]any)
+
+		bonus := types.PartyBonus{
+			Stat:  types.Stat(bonusData["RTStat"].(int)),
+			Value: bonusData["RTValue"].(int),
+		}
+
+		if val, has := bonusData["RTPerMember"]; has {
+			bonus.PerMember = val.(int)
+		}
+
+		if val, has := bonusData["RTPercent"]; has {
+			bonus.Percent = val.(bool)
+		}
+
+		bonuses = append(bonuses, bonus)
+	}
+
+	return bonuses
+}
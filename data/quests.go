@@ -0,0 +1,103 @@
+package data
+
+import (
+	"os"
+	saoParts "sao/parts"
+	"sao/types"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/tfo-dot/parts"
+)
+
+var Quests = GetQuests()
+
+func GetQuests() map[uuid.UUID]types.Quest {
+	dirData, err := os.ReadDir(Config.GameDataLocation + "/quests")
+
+	if err != nil {
+		panic(err)
+	}
+
+	quests := map[uuid.UUID]types.Quest{}
+
+	for _, file := range dirData {
+		if file.IsDir() {
+			continue
+		}
+
+		if !strings.HasSuffix(file.Name(), ".pts") {
+			continue
+		}
+
+		println("Loading quest: " + file.Name())
+
+		code, err := os.ReadFile(Config.GameDataLocation + "/quests/" + file.Name())
+
+		if err != nil {
+			panic(err)
+		}
+
+		vm, err := parts.GetVMWithSource(string(code))
+
+		if err != nil {
+			panic(err)
+		}
+
+		saoParts.AddConsts(vm)
+		saoParts.AddFunctions(vm)
+
+		err = vm.Run()
+
+		if err != nil {
+			panic(err)
+		}
+
+		quest := types.Quest{
+			Objectives: make([]types.QuestObjective, 0),
+			Rewards:    make([]types.Loot, 0),
+		}
+
+		parts.ReadFromParts(vm, &quest)
+
+		rawUUID, err := saoParts.FetchVal(vm, "UUID")
+
+		if err != nil {
+			panic(err)
+		}
+
+		quest.UUID = uuid.MustParse(rawUUID.(string))
+
+		if len(quest.Objectives) == 0 {
+			panic("Quest without objectives: " + file.Name())
+		}
+
+		for _, objective := range quest.Objectives {
+			if objective.Type != types.QUEST_DELIVER {
+				continue
+			}
+
+			if _, exists := Items[uuid.MustParse(objective.Target)]; !exists {
+				panic("Unknown item to deliver in quest: " + file.Name())
+			}
+		}
+
+		for _, reward := range quest.Rewards {
+			if reward.Type != types.LOOT_ITEM {
+				continue
+			}
+
+			if _, exists := Items[uuid.MustParse(reward.Item)]; !exists {
+				panic("Unknown reward item in quest: " + file.Name())
+			}
+		}
+
+		if FloorMap.FindLocation(func(l types.Location) bool { return l.Name == quest.Location }) == nil {
+			panic("Unknown quest giver location in quest: " + file.Name())
+		}
+
+		quests[quest.UUID] = quest
+	}
+
+	return quests
+}
@@ -0,0 +1,112 @@
+package data
+
+import (
+	"os"
+	saoParts "sao/parts"
+	"sao/types"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/tfo-dot/parts"
+)
+
+var Recipes = GetRecipes()
+
+func GetRecipes() map[uuid.UUID]types.Recipe {
+	dirData, err := os.ReadDir(Config.GameDataLocation + "/recipes")
+
+	if err != nil {
+		panic(err)
+	}
+
+	recipes := map[uuid.UUID]types.Recipe{}
+
+	for _, file := range dirData {
+		if file.IsDir() {
+			continue
+		}
+
+		if !strings.HasSuffix(file.Name(), ".pts") {
+			continue
+		}
+
+		println("Loading recipe: " + file.Name())
+
+		code, err := os.ReadFile(Config.GameDataLocation + "/recipes/" + file.Name())
+
+		if err != nil {
+			panic(err)
+		}
+
+		vm, err := parts.GetVMWithSource(string(code))
+
+		if err != nil {
+			panic(err)
+		}
+
+		saoParts.AddConsts(vm)
+		saoParts.AddFunctions(vm)
+
+		err = vm.Run()
+
+		if err != nil {
+			panic(err)
+		}
+
+		recipe := types.Recipe{
+			Inputs: make([]types.WithCount[uuid.UUID], 0),
+		}
+
+		parts.ReadFromParts(vm, &recipe)
+
+		rawUUID, err := saoParts.FetchVal(vm, "UUID")
+
+		if err != nil {
+			panic(err)
+		}
+
+		recipe.UUID = uuid.MustParse(rawUUID.(string))
+
+		inputList, err := saoParts.FetchVal(vm, "Inputs")
+
+		if err != nil {
+			panic(err)
+		}
+
+		for _, input := range inputList.([]any) {
+			inputData := input.(map[string]any)
+
+			recipe.Inputs = append(recipe.Inputs, types.WithCount[uuid.UUID]{
+				Item:  uuid.MustParse(inputData["RTItem"].(string)),
+				Count: inputData["RTCount"].(int),
+			})
+		}
+
+		output, err := saoParts.FetchVal(vm, "Output")
+
+		if err != nil {
+			panic(err)
+		}
+
+		outputData := output.(map[string]any)
+
+		recipe.Output = types.WithCount[uuid.UUID]{
+			Item:  uuid.MustParse(outputData["RTItem"].(string)),
+			Count: outputData["RTCount"].(int),
+		}
+
+		for _, input := range recipe.Inputs {
+			if _, exists := Items[input.Item]; !exists {
+				panic("Unknown input item in recipe: " + file.Name())
+			}
+		}
+
+		if _, exists := Items[recipe.Output.Item]; !exists {
+			panic("Unknown output item in recipe: " + file.Name())
+		}
+
+		recipes[recipe.UUID] = recipe
+	}
+
+	return recipes
+}
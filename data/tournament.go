@@ -0,0 +1,145 @@
+package data
+
+import (
+	"os"
+	saoParts "sao/parts"
+	"sao/types"
+
+	"github.com/google/uuid"
+	"github.com/tfo-dot/parts"
+)
+
+var TournamentConfig = GetTournamentConfig()
+
+type TournamentConfigStruct struct {
+	//In minutes
+	CheckInTime int
+	//In minutes
+	MatchTime int
+	BetMin    int
+	BetMax    int
+	//Percent taken from fair odds
+	BetMargin     int
+	BetLevelScale int
+	PrizePools    []types.TournamentPrizePool
+}
+
+// Returns nil for unknown pools
+func (c TournamentConfigStruct) GetPrizePool(name string) *types.TournamentPrizePool {
+	for idx := range c.PrizePools {
+		if c.PrizePools[idx].Name == name {
+			return &c.PrizePools[idx]
+		}
+	}
+
+	return nil
+}
+
+func GetTournamentConfig() TournamentConfigStruct {
+	println("Loading tournament config:", Config.GameDataLocation+"/tournament/config.pts")
+
+	code, err := os.ReadFile(Config.GameDataLocation + "/tournament/config.pts")
+
+	if err != nil {
+		panic(err)
+	}
+
+	vm, err := parts.GetVMWithSource(string(code))
+
+	if err != nil {
+		panic(err)
+	}
+
+	saoParts.AddConsts(vm)
+	saoParts.AddFunctions(vm)
+
+	err = vm.Run()
+
+	if err != nil {
+		panic(err)
+	}
+
+	config := TournamentConfigStruct{PrizePools: make([]types.TournamentPrizePool, 0)}
+
+	for key, target := range map[string]*int{
+		"CHECK_IN_TIME":   &config.CheckInTime,
+		"MATCH_TIME":      &config.MatchTime,
+		"BET_MIN":         &config.BetMin,
+		"BET_MAX":         &config.BetMax,
+		"BET_MARGIN":      &config.BetMargin,
+		"BET_LEVEL_SCALE": &config.BetLevelScale,
+	} {
+		rawVal, err := saoParts.FetchVal(vm, key)
+
+		if err != nil {
+			panic(err)
+		}
+
+		*target = rawVal.(int)
+	}
+
+	if config.BetLevelScale <= 0 {
+		panic("Tournament bet level scale has to be positive")
+	}
+
+	rawPools, err := saoParts.FetchVal(vm, "PrizePools")
+
+	if err != nil {
+		panic(err)
+	}
+
+	for _, rawPool := range rawPools.([]any) {
+		poolData := rawPool.(map[string]any)
+
+		pool := types.TournamentPrizePool{
+			Name:   poolData["RTName"].(string),
+			Prizes: make([]types.TournamentPrize, 0),
+		}
+
+		if config.GetPrizePool(pool.Name) != nil {
+			panic("Duplicated tournament prize pool: " + pool.Name)
+		}
+
+		for _, rawPrize := range poolData["RTPrizes"].([]any) {
+			prizeData := rawPrize.(map[string]any)
+
+			prize := types.TournamentPrize{
+				Place:   prizeData["RTPlace"].(int),
+				Rewards: make([]types.Loot, 0),
+			}
+
+			if val, has := prizeData["RTTitle"]; has {
+				prize.Title = val.(string)
+			}
+
+			for _, rawReward := range prizeData["RTRewards"].([]any) {
+				rewardData := rawReward.(map[string]any)
+
+				reward := types.Loot{
+					Type:  types.LootType(rewardData["RTType"].(int)),
+					Count: rewardData["RTCount"].(int),
+				}
+
+				if reward.Type == types.LOOT_ITEM {
+					reward.Item = rewardData["RTItem"].(string)
+
+					if _, exists := Items[uuid.MustParse(reward.Item)]; !exists {
+						panic("Unknown prize item in tournament prize pool: " + pool.Name)
+					}
+				}
+
+				prize.Rewards = append(prize.Rewards, reward)
+			}
+
+			pool.Prizes = append(pool.Prizes, prize)
+		}
+
+		config.PrizePools = append(config.PrizePools, pool)
+	}
+
+	if len(config.PrizePools) == 0 {
+		panic("Tournament config without prize pools")
+	}
+
+	return config
+}
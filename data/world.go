@@ -12,6 +12,24 @@ var WorldConfig WorldConfigStruct = GetWorldConfig()
 type WorldConfigStruct struct {
 	Hardcore   bool `parts:"HARDCORE_MODE"`
 	SpeedGauge int  `parts:"SPEED_GAUGE"`
+	RespecCost int  `parts:"RESPEC_COST"`
+	//Floor bosses unlock next floors only for players that defeated them
+	FloorUnlockPerPlayer bool `parts:"FLOOR_UNLOCK_PER_PLAYER"`
+	//Floor name, new players start in its default location
+	StartFloor   string `parts:"START_FLOOR"`
+	TravelTime   int    `parts:"TRAVEL_TIME"`
+	TeleportCost int    `parts:"TELEPORT_COST"`
+	//Players can retreat from rolled encounters before the fight starts
+	AllowRetreat bool `parts:"ALLOW_RETREAT"`
+	//Death penalties in normal mode, percentages of current level progress and gold
+	DeathXPLoss   int `parts:"DEATH_XP_LOSS"`
+	DeathGoldLoss int `parts:"DEATH_GOLD_LOSS"`
+	//Percent of AD and AP lost for WeaknessDuration minutes after respawning
+	WeaknessValue    int `parts:"WEAKNESS_VALUE"`
+	WeaknessDuration int `parts:"WEAKNESS_DURATION"`
+	//In minutes
+	RespawnTime int `parts:"RESPAWN_TIME"`
+	DuelTime    int `parts:"DUEL_TIME"`
 }
 
 func GetWorldConfig() WorldConfigStruct {
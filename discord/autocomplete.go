@@ -1,22 +1,70 @@
 package discord
 
 import (
+	"fmt"
+	"sao/data"
 	"sao/world/tournament"
 	"strings"
 
 	"github.com/disgoorg/disgo/discord"
 	"github.com/disgoorg/disgo/events"
+	"github.com/google/uuid"
 )
 
 func AutocompleteHandler(event *events.AutocompleteInteractionCreate) {
+	World.Mutex.Lock()
+	defer World.Mutex.Unlock()
+
 	switch event.Data.CommandName {
 	case "turniej":
+		choices := make([]discord.AutocompleteChoice, 0)
+
+		if *event.Data.SubCommandName == "stwórz" {
+			name := strings.ToLower(event.Data.String("nagrody"))
+
+			for _, pool := range data.TournamentConfig.PrizePools {
+				if len(choices) < 25 && strings.HasPrefix(strings.ToLower(pool.Name), name) {
+					choices = append(choices, discord.AutocompleteChoiceString{Name: pool.Name, Value: pool.Name})
+				}
+			}
+
+			event.AutocompleteResult(choices)
+			return
+		}
+
 		name := event.Data.String("nazwa")
 
-		choices := make([]discord.AutocompleteChoice, 0)
+		if *event.Data.SubCommandName == "zakład" && event.Data.Focused().Name == "typ" {
+			for _, tournamentObj := range World.Tournaments {
+				if tournamentObj.Name != name {
+					continue
+				}
+
+				for _, option := range World.GetBetOptions(tournamentObj) {
+					if len(choices) >= 25 {
+						break
+					}
+
+					choices = append(choices, discord.AutocompleteChoiceString{
+						Name:  fmt.Sprintf("Mecz #%v: %v (%v)", option.Match+1, option.Name, tournament.OddsToString(option.Odds)),
+						Value: fmt.Sprintf("%v|%v", option.Match, option.Side),
+					})
+				}
+			}
+
+			event.AutocompleteResult(choices)
+			return
+		}
+
+		//Brackets and bets only exist for running tournaments, only waiting ones can be started
+		state := tournament.Waiting
+
+		if *event.Data.SubCommandName == "drabinka" || *event.Data.SubCommandName == "zakład" {
+			state = tournament.Running
+		}
 
 		for _, tournamentObj := range World.Tournaments {
-			if strings.HasPrefix(tournamentObj.Name, name) && tournamentObj.State == tournament.Waiting {
+			if strings.HasPrefix(tournamentObj.Name, name) && tournamentObj.State == state {
 				choices = append(choices, discord.AutocompleteChoiceString{
 					Name:  tournamentObj.Name,
 					Value: tournamentObj.Name,
@@ -24,6 +72,150 @@ func AutocompleteHandler(event *events.AutocompleteInteractionCreate) {
 			}
 		}
 
+		event.AutocompleteResult(choices)
+	case "podróż":
+		name := strings.ToLower(event.Data.String("cel"))
+
+		choices := make([]discord.AutocompleteChoice, 0)
+
+		pl := World.GetPlayer(event.User().ID.String())
+
+		if pl == nil {
+			event.AutocompleteResult(choices)
+			return
+		}
+
+		for _, floor := range data.FloorMap {
+			if !pl.IsFloorUnlocked(floor) {
+				continue
+			}
+
+			for _, loc := range floor.Locations {
+				if len(choices) >= 25 {
+					break
+				}
+
+				if strings.HasPrefix(strings.ToLower(loc.Name), name) {
+					choices = append(choices, discord.AutocompleteChoiceString{
+						Name:  fmt.Sprintf("%s (%s)", loc.Name, floor.Name),
+						Value: floor.Name + "," + loc.Name,
+					})
+				}
+			}
+		}
+
+		event.AutocompleteResult(choices)
+	case "questy":
+		name := strings.ToLower(event.Data.String("zadanie"))
+
+		choices := make([]discord.AutocompleteChoice, 0)
+
+		pl := World.GetPlayer(event.User().ID.String())
+
+		if pl == nil {
+			event.AutocompleteResult(choices)
+			return
+		}
+
+		for questUuid := range pl.Quests.Active {
+			quest, exists := data.Quests[questUuid]
+
+			if !exists || len(choices) >= 25 {
+				continue
+			}
+
+			if strings.HasPrefix(strings.ToLower(quest.Name), name) {
+				choices = append(choices, discord.AutocompleteChoiceString{
+					Name:  quest.Name,
+					Value: quest.UUID.String(),
+				})
+			}
+		}
+
+		event.AutocompleteResult(choices)
+	case "craft":
+		name := strings.ToLower(event.Data.String("przepis"))
+
+		choices := make([]discord.AutocompleteChoice, 0)
+
+		for _, recipe := range data.Recipes {
+			if len(choices) >= 25 {
+				break
+			}
+
+			if strings.HasPrefix(strings.ToLower(recipe.Name), name) {
+				choices = append(choices, discord.AutocompleteChoiceString{
+					Name:  recipe.Name,
+					Value: recipe.UUID.String(),
+				})
+			}
+		}
+
+		event.AutocompleteResult(choices)
+	case "party":
+		name := strings.ToLower(event.Data.String("rola"))
+
+		choices := make([]discord.AutocompleteChoice, 0)
+
+		if strings.HasPrefix("lider", name) {
+			choices = append(choices, discord.AutocompleteChoiceString{Name: "Lider", Value: "Lider"})
+		}
+
+		for _, roleId := range data.PartyConfig.RoleOrder {
+			role := data.PartyConfig.Roles[roleId]
+
+			if len(choices) >= 25 {
+				break
+			}
+
+			if strings.HasPrefix(strings.ToLower(role.Name), name) {
+				choices = append(choices, discord.AutocompleteChoiceString{
+					Name:  role.Name,
+					Value: role.Id,
+				})
+			}
+		}
+
+		event.AutocompleteResult(choices)
+	case "gildia":
+		name := strings.ToLower(event.Data.String("przedmiot"))
+
+		choices := make([]discord.AutocompleteChoice, 0)
+
+		pl := World.GetPlayer(event.User().ID.String())
+
+		if pl == nil {
+			event.AutocompleteResult(choices)
+			return
+		}
+
+		itemCounts := make(map[uuid.UUID]int)
+
+		if *event.Data.SubCommandName == "wpłać" {
+			for _, item := range pl.Inventory.Items {
+				if item.Stacks {
+					itemCounts[item.UUID] += item.Count
+				}
+			}
+		} else if _, guildObj, err := World.GetPlayerGuild(pl); err == nil {
+			itemCounts = guildObj.Bank.Items
+		}
+
+		for itemUuid, count := range itemCounts {
+			item, exists := data.Items[itemUuid]
+
+			if !exists || len(choices) >= 25 {
+				continue
+			}
+
+			if strings.HasPrefix(strings.ToLower(item.Name), name) {
+				choices = append(choices, discord.AutocompleteChoiceString{
+					Name:  fmt.Sprintf("%s (%d)", item.Name, count),
+					Value: itemUuid.String(),
+				})
+			}
+		}
+
 		event.AutocompleteResult(choices)
 	}
 }
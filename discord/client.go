@@ -11,9 +11,12 @@ import (
 	"sao/types"
 	"sao/utils"
 	"sao/world"
+	"sao/world/guild"
 	"sao/world/party"
 	"sao/world/tournament"
 	"slices"
+	"strings"
+	"time"
 
 	"github.com/disgoorg/disgo"
 	"github.com/disgoorg/disgo/bot"
@@ -28,6 +31,9 @@ var World *world.World
 var Client *bot.Client
 var Choices = make([]types.DiscordChoice, 0)
 
+// Fallen characters listed per page of /cmentarz lista
+const GraveyardPageSize = 10
+
 func StartClient() {
 	client, err := disgo.New(data.Config.Token,
 		bot.WithEventListenerFunc(func(e *events.Ready) {
@@ -35,6 +41,9 @@ func StartClient() {
 			e.Client().SetPresence(context.Background(), gateway.WithWatchingActivity("SAO"))
 		}),
 		bot.WithEventListenerFunc(func(e *events.MessageCreate) {
+			World.Mutex.Lock()
+			defer World.Mutex.Unlock()
+
 			if e.Message.Content == "sao:dump" && e.Message.Author.ID.String() == data.Config.Owner {
 				rawBackup := World.CreateBackup()
 
@@ -58,10 +67,28 @@ func StartClient() {
 			if e.Message.Content == "sao:reload" && e.Message.Author.ID.String() == data.Config.Owner {
 				mobs.Mobs = mobs.GetMobs()
 				data.FloorMap = data.GetFloors()
+				World.ApplyFloorUnlocks()
 				data.PlayerDefaults = data.GetPlayerDefaults()
 				data.Shops = data.GetShops()
 				data.WorldConfig = data.GetWorldConfig()
 				data.Items = data.GetItems()
+				data.Recipes = data.GetRecipes()
+				data.Quests = data.GetQuests()
+				data.WorldBosses = data.GetWorldBosses()
+				data.Furies = data.GetFuries()
+				data.PartyConfig = data.GetPartyConfig()
+				data.GuildConfig = data.GetGuildConfig()
+				data.TournamentConfig = data.GetTournamentConfig()
+
+				for guildUuid := range World.Guilds {
+					World.SyncGuild(guildUuid)
+				}
+
+				for _, pl := range World.Players {
+					if pl.Fury != nil {
+						pl.Fury.Reload()
+					}
+				}
 
 				e.Client().Rest().AddReaction(e.Message.ChannelID, e.Message.ID, data.Config.Emote)
 			}
@@ -91,6 +118,9 @@ func StartClient() {
 }
 
 func commandListener(event *events.ApplicationCommandInteractionCreate) {
+	World.Mutex.Lock()
+	defer World.Mutex.Unlock()
+
 	interactionData := event.SlashCommandInteractionData()
 
 	user := event.User()
@@ -104,7 +134,7 @@ func commandListener(event *events.ApplicationCommandInteractionCreate) {
 		}
 	}
 
-	if interactionData.CommandName() != "create" && interactionData.CommandName() != "turniej" && playerChar == nil {
+	if interactionData.CommandName() != "create" && interactionData.CommandName() != "turniej" && interactionData.CommandName() != "cmentarz" && playerChar == nil {
 		event.CreateMessage(noCharMessage)
 		return
 	}
@@ -169,7 +199,7 @@ func commandListener(event *events.ApplicationCommandInteractionCreate) {
 
 		lvlText := fmt.Sprint(playerChar.XP.Level)
 
-		if playerChar.XP.Level >= data.FloorMap.GetUnlockedFloorCount()*5 {
+		if playerChar.XP.Level >= playerChar.GetLevelCap() {
 			lvlText += " MAX"
 		} else {
 			lvlText += fmt.Sprintf(" %d/%d", playerChar.XP.Exp, (playerChar.XP.Level*100)+100)
@@ -187,6 +217,32 @@ func commandListener(event *events.ApplicationCommandInteractionCreate) {
 			derivedStatsText = "Brak"
 		}
 
+		locationText := fmt.Sprintf("%s (%s)", playerChar.Meta.Location.Location, playerChar.Meta.Location.Floor)
+
+		if playerChar.Meta.Travel != nil {
+			locationText = fmt.Sprintf(
+				"W podróży do %s (%d min)", playerChar.Meta.Travel.Destination.Location, playerChar.Meta.Travel.Remaining,
+			)
+		}
+
+		furyText := "Brak"
+
+		if playerChar.Fury != nil {
+			furyText = fmt.Sprintf("%s (%d tier, lvl %d)", playerChar.Fury.Name, playerChar.Fury.CurrentTier, playerChar.Fury.XP.LVL)
+		}
+
+		guildText := "Brak"
+
+		if _, guildObj, err := World.GetPlayerGuild(playerChar); err == nil {
+			guildText = fmt.Sprintf("%s (%s)", guildObj.Name, guild.RankToString[playerChar.Meta.Guild.Rank])
+		}
+
+		titlesText := "Brak"
+
+		if len(playerChar.PvP.Titles) > 0 {
+			titlesText = strings.Join(playerChar.PvP.Titles, ", ")
+		}
+
 		messageBuilder := discord.NewMessageCreateBuilder().
 			AddEmbeds(
 				discord.NewEmbedBuilder().
@@ -201,6 +257,11 @@ func commandListener(event *events.ApplicationCommandInteractionCreate) {
 					AddField("SPD/AGL", fmt.Sprintf("%d/%d", playerChar.GetStat(types.STAT_SPD), playerChar.GetStat(types.STAT_AGL)), true).
 					AddField("W walce?", inFightText, true).
 					AddField("W party?", inPartyText, true).
+					AddField("Lokacja", locationText, true).
+					AddField("PvP", fmt.Sprintf("%d/%d", playerChar.PvP.Wins, playerChar.PvP.Losses), true).
+					AddField("Tytuły", titlesText, true).
+					AddField("Furia", furyText, true).
+					AddField("Gildia", guildText, true).
 					AddField("Dynamiczne statystyki", derivedStatsText, true).
 					Build(),
 			)
@@ -422,7 +483,27 @@ func commandListener(event *events.ApplicationCommandInteractionCreate) {
 			event.CreateMessage(
 				discord.NewMessageCreateBuilder().AddEmbeds(embed.Build()).AddActionRow(buttons...).Build(),
 			)
+		case "reset":
+			loc := playerChar.GetLocation()
 
+			if loc == nil || !loc.CityPart {
+				event.CreateMessage(MessageContent("Reset umiejętności jest dostępny tylko w mieście", true))
+				return
+			}
+
+			event.CreateMessage(discord.
+				NewMessageCreateBuilder().
+				SetContentf(
+					"Reset usunie wszystkie odblokowane umiejętności i ulepszenia. Koszt: %d złota. Kontynuować?",
+					playerChar.GetRespecCost(),
+				).
+				AddActionRow(
+					discord.NewDangerButton("Resetuj", "respec/confirm"),
+					discord.NewSecondaryButton("Anuluj", "respec/cancel"),
+				).
+				SetEphemeral(true).
+				Build(),
+			)
 		}
 	case "plecak":
 		switch *interactionData.SubCommandName {
@@ -443,17 +524,67 @@ func commandListener(event *events.ApplicationCommandInteractionCreate) {
 				embed.AddField("Przedmioty", fmt.Sprintf("%d/10", count), false)
 			}
 
+			usableOptions := make([]discord.StringSelectMenuOption, 0)
+			bestRarity := types.RARITY_COMMON
+
 			for _, item := range playerChar.Inventory.Items {
 				if item.Hidden {
 					continue
 				}
 
-				embed.AddField(item.Name, item.Description, false)
+				if item.Rarity > bestRarity {
+					bestRarity = item.Rarity
+				}
+
+				itemDescription := item.Description
+
+				if affixText := ItemAffixSummary(item); affixText != "" {
+					itemDescription += "\n" + affixText
+				}
+
+				embed.AddField(item.DisplayName(), itemDescription, false)
+
+				if item.OutOfFight && (!item.Consume || item.Count > 0) {
+					usableOptions = append(usableOptions, discord.NewStringSelectMenuOption(
+						fmt.Sprintf("%s (%d)", item.Name, item.Count), item.UUID.String(),
+					))
+				}
 			}
 
-			event.CreateMessage(MessageEmbed(
-				embed.AddField("Złoto", fmt.Sprintf("%d", playerChar.Inventory.Gold), false).Build()),
-			)
+			embed.AddField("Złoto", fmt.Sprintf("%d", playerChar.Inventory.Gold), false)
+			embed.SetColor(types.RarityToColor[bestRarity])
+
+			if len(playerChar.Stats.TimedEffects) > 0 {
+				effectsText := ""
+
+				for _, effect := range playerChar.Stats.TimedEffects {
+					if statMeta, ok := effect.Meta.(types.ActionEffectStat); ok {
+						if statMeta.IsPercent {
+							effectsText += fmt.Sprintf("- %d%% %s", effect.Value, types.StatToString[statMeta.Stat])
+						} else {
+							effectsText += fmt.Sprintf("- %d %s", effect.Value, types.StatToString[statMeta.Stat])
+						}
+					} else {
+						effectsText += "- Nieznany efekt"
+					}
+
+					effectsText += fmt.Sprintf(" (%d min)\n", effect.Duration)
+				}
+
+				embed.AddField("Aktywne efekty", effectsText, false)
+			}
+
+			messageBuilder := discord.NewMessageCreateBuilder().AddEmbeds(embed.Build())
+
+			if len(usableOptions) > 0 && playerChar.Meta.FightInstance == nil {
+				messageBuilder.AddActionRow(discord.
+					NewStringSelectMenu("inv/use", "Użyj przedmiotu").
+					WithMaxValues(1).
+					AddOptions(usableOptions...),
+				)
+			}
+
+			event.CreateMessage(messageBuilder.Build())
 
 			return
 		}
@@ -484,17 +615,17 @@ func commandListener(event *events.ApplicationCommandInteractionCreate) {
 			channelId = dChannel.ID().String()
 		}
 
-		loc := data.FloorMap.FindLocation(func(l types.Location) bool { return l.CID == channelId })
-
-		println(channelId)
+		loc := playerChar.GetLocation()
 
 		if loc == nil {
-			event.CreateMessage(discord.
-				NewMessageCreateBuilder().
-				SetContent("Nie rozpoznaje tego kanału...").
-				SetEphemeral(true).
-				Build(),
-			)
+			event.CreateMessage(travelingMessage)
+			return
+		}
+
+		if loc.CID != channelId {
+			event.CreateMessage(MessageContent(
+				fmt.Sprintf("Twoja postać jest w lokacji %s (<#%s>)", loc.Name, loc.CID), true,
+			))
 			return
 		}
 
@@ -508,11 +639,12 @@ func commandListener(event *events.ApplicationCommandInteractionCreate) {
 			return
 		}
 
-		World.PlayerSearch(playerChar.GetUUID(), threadId, event)
-
-		event.CreateMessage(MessageContent("Szukanie...", true))
+		if !playerChar.IsFloorUnlocked(data.FloorMap[playerChar.Meta.Location.Floor]) {
+			event.CreateMessage(MessageContent("To piętro nie zostało jeszcze odblokowane", true))
+			return
+		}
 
-		go World.PlayerSearch(playerChar.GetUUID(), threadId, event)
+		World.PlayerSearch(playerChar.GetUUID(), threadId, event)
 
 		return
 	case "party":
@@ -536,60 +668,82 @@ func commandListener(event *events.ApplicationCommandInteractionCreate) {
 			for _, member := range partyObj.Players {
 				memberObj := World.Players[member.PlayerUuid]
 
-				partyMembersText += fmt.Sprintf("<@%s> - %s\n", memberObj.Meta.UserID, memberObj.GetName())
+				roleText := "brak roli"
+
+				if roleDef, exists := data.PartyConfig.Roles[string(member.Role)]; exists {
+					roleText = roleDef.Name
+				}
+
+				partyMembersText += fmt.Sprintf("<@%s> - %s (%s)\n", memberObj.Meta.UserID, memberObj.GetName(), roleText)
 			}
 
 			if partyMembersText[len(partyMembersText)-1] == '\n' {
 				partyMembersText = partyMembersText[:len(partyMembersText)-1]
 			}
 
+			synergyNames := make([]string, 0)
+
+			for _, synergy := range player.GetPartySynergies(playerChar.Meta.Party.Roles) {
+				synergyNames = append(synergyNames, synergy.Name)
+			}
+
+			synergyText := "Brak"
+
+			if len(synergyNames) > 0 {
+				synergyText = strings.Join(synergyNames, ", ")
+			}
+
 			event.CreateMessage(
 				MessageEmbed(
 					discord.NewEmbedBuilder().
 						AddField("Członkowie", partyMembersText, false).
 						AddField("Lider", fmt.Sprintf("<@%s> - %s\n", partyLeader.Meta.UserID, partyLeader.GetName()), false).
+						AddField("Łupy", party.LootModeToString[partyObj.LootMode], false).
+						AddField("Synergie", synergyText, false).
 						Build(),
 				),
 			)
 
 			return
 		case "zapros":
-			if playerChar.Meta.Party == nil {
-				World.RegisterParty(party.Party{
-					Leader: playerChar.GetUUID(),
-					Players: []*party.PartyEntry{
-						{
-							PlayerUuid: playerChar.GetUUID(),
-							Role:       party.None,
-						},
-					},
-				})
-			}
-
-			if len(World.Parties[playerChar.Meta.Party.UUID].Players) >= 6 {
-				event.CreateMessage(MessageContent("Party jest pełne", true))
-				return
-			}
+			mentionedUser := interactionData.User("gracz")
 
-			part := World.Parties[playerChar.Meta.Party.UUID]
+			pl := World.GetPlayer(mentionedUser.ID.String())
 
-			if playerChar.GetUUID() != part.Leader {
-				event.CreateMessage(MessageContent("Nie jesteś liderem", true))
+			if pl == nil {
+				event.CreateMessage(MessageContent("Użytkownik nie ma postaci", true))
 				return
 			}
 
-			mentionedUser := interactionData.User("gracz")
-
-			pl := World.GetPlayer(mentionedUser.ID.String())
+			inviteUuid, err := World.InviteToParty(playerChar, pl)
+
+			if err != nil {
+				msgContent := ""
+
+				switch err.Error() {
+				case "SELF_INVITE":
+					msgContent = "Nie możesz zaprosić samego siebie"
+				case "NOT_LEADER":
+					msgContent = "Nie jesteś liderem"
+				case "PARTY_FULL":
+					msgContent = "Party jest pełne"
+				case "ALREADY_IN_PARTY":
+					msgContent = "Gracz jest już w party"
+				case "ALREADY_INVITED":
+					msgContent = "Gracz ma już od ciebie zaproszenie"
+				default:
+					msgContent = "Nieznany błąd (party)"
+				}
 
-			if pl.Meta.Party != nil {
-				event.CreateMessage(MessageContent("Gracz jest już w party", true))
+				event.CreateMessage(MessageContent(msgContent, true))
 				return
 			}
 
 			ch, error := event.Client().Rest().CreateDMChannel(mentionedUser.ID)
 
 			if error != nil {
+				World.RejectPartyInvite(inviteUuid, pl)
+
 				event.CreateMessage(MessageContent("Nie można wysłać wiadomości do gracza", true))
 				return
 			}
@@ -597,15 +751,20 @@ func commandListener(event *events.ApplicationCommandInteractionCreate) {
 			chID := ch.ID()
 
 			_, error = event.Client().Rest().CreateMessage(chID, discord.NewMessageCreateBuilder().
-				SetContent(fmt.Sprintf("<@%s> (%s) zaprasza cię do party", user.ID.String(), playerChar.GetName())).
+				SetContentf(
+					"<@%s> (%s) zaprasza cię do party, zaproszenie wygasa za %d minut",
+					user.ID.String(), playerChar.GetName(), data.PartyConfig.InviteTime,
+				).
 				AddActionRow(
-					discord.NewPrimaryButton("Akceptuj", "party/res|"+(playerChar.Meta.Party.UUID).String()),
-					discord.NewDangerButton("Odrzuć", "party/rej|"+(playerChar.Meta.Party.UUID).String()),
+					discord.NewPrimaryButton("Akceptuj", "party/res|"+inviteUuid.String()),
+					discord.NewDangerButton("Odrzuć", "party/rej|"+inviteUuid.String()),
 				).
 				Build(),
 			)
 
 			if error != nil {
+				World.RejectPartyInvite(inviteUuid, pl)
+
 				event.CreateMessage(MessageContent("Nie można wysłać wiadomości do gracza", true))
 				return
 			}
@@ -639,16 +798,9 @@ func commandListener(event *events.ApplicationCommandInteractionCreate) {
 				return
 			}
 
-			for i, partyMember := range World.Parties[playerChar.Meta.Party.UUID].Players {
-				if partyMember.PlayerUuid == pl.GetUUID() {
-					pl.Meta.Party = nil
-
-					World.Parties[playerChar.Meta.Party.UUID].Players = slices.Delete(World.Parties[playerChar.Meta.Party.UUID].Players, i, i+1)
-				} else {
-					World.Players[partyMember.PlayerUuid].Meta.Party.MembersCount--
-				}
-			}
+			World.RemoveFromParty(pl)
 
+			event.CreateMessage(MessageContent("Wyrzucono gracza z party", true))
 			return
 		case "opuść":
 			part := World.Parties[playerChar.Meta.Party.UUID]
@@ -658,16 +810,7 @@ func commandListener(event *events.ApplicationCommandInteractionCreate) {
 				return
 			}
 
-			//TODO fix this XDDD
-			for i, partyMember := range World.Parties[playerChar.Meta.Party.UUID].Players {
-				if partyMember.PlayerUuid == playerChar.GetUUID() {
-					World.Parties[playerChar.Meta.Party.UUID].Players = slices.Delete(World.Parties[playerChar.Meta.Party.UUID].Players, i, i+1)
-				} else {
-					World.Players[partyMember.PlayerUuid].Meta.Party.MembersCount--
-				}
-			}
-
-			playerChar.Meta.Party = nil
+			World.RemoveFromParty(playerChar)
 
 			event.CreateMessage(MessageContent("Opuściłeś party", true))
 			return
@@ -706,23 +849,41 @@ func commandListener(event *events.ApplicationCommandInteractionCreate) {
 
 			role := interactionData.String("rola")
 
-			for i, partyMember := range World.Parties[playerChar.Meta.Party.UUID].Players {
+			if role == "Lider" {
+				part.Leader = pl.GetUUID()
+
+				event.CreateMessage(MessageContent("Zmieniono lidera", true))
+				return
+			}
+
+			if err := pl.CanTakePartyRole(party.PartyRole(role)); err != nil {
+				msgContent := ""
+
+				switch err.Error() {
+				case "ROLE_NOT_FOUND":
+					msgContent = "Nie ma takiej roli"
+				case "LEVEL_TOO_LOW":
+					msgContent = fmt.Sprintf("Rola wymaga poziomu %d", data.PartyConfig.Roles[role].MinLevel)
+				case "WRONG_PATH":
+					msgContent = fmt.Sprintf("Rola wymaga ścieżki %s", types.PathToString[*data.PartyConfig.Roles[role].Path])
+				default:
+					msgContent = "Nieznany błąd (party)"
+				}
+
+				event.CreateMessage(MessageContent(msgContent, true))
+				return
+			}
+
+			for _, partyMember := range part.Players {
 				if partyMember.PlayerUuid == pl.GetUUID() {
-					switch role {
-					case "Lider":
-						World.Parties[playerChar.Meta.Party.UUID].Leader = pl.GetUUID()
-					case "DPS":
-						World.Parties[playerChar.Meta.Party.UUID].Players[i].Role = party.DPS
-					case "Support":
-						World.Parties[playerChar.Meta.Party.UUID].Players[i].Role = party.Support
-					case "Tank":
-						World.Parties[playerChar.Meta.Party.UUID].Players[i].Role = party.Tank
-					}
+					partyMember.Role = party.PartyRole(role)
 					break
 				}
 			}
 
-			event.CreateMessage(MessageContent("Zmieniono rolę", true))
+			World.SyncParty(playerChar.Meta.Party.UUID)
+
+			event.CreateMessage(MessageContent("Zmieniono rolę na "+data.PartyConfig.Roles[role].Name, true))
 			return
 		case "rozwiąż":
 			part := World.Parties[playerChar.Meta.Party.UUID]
@@ -742,24 +903,34 @@ func commandListener(event *events.ApplicationCommandInteractionCreate) {
 
 			event.CreateMessage(MessageContent("Rozwiązano party", true))
 			return
+		case "łupy":
+			part := World.Parties[playerChar.Meta.Party.UUID]
+
+			if playerChar.GetUUID() != part.Leader {
+				event.CreateMessage(MessageContent("Nie jesteś liderem", true))
+				return
+			}
+
+			part.LootMode = party.LootMode(interactionData.Int("tryb"))
+
+			event.CreateMessage(MessageContent("Tryb łupów: "+party.LootModeToString[part.LootMode], false))
+			return
 		}
 	case "sklep":
 		switch *interactionData.SubCommandName {
 		case "pokaż":
 
-			channelId := event.Channel().ID()
-
-			loc := data.FloorMap.FindLocation(func(l types.Location) bool { return l.CID == channelId.String() })
+			loc := playerChar.GetLocation()
 
 			if loc == nil {
-				event.CreateMessage(MessageContent("Nie rozponaje tego kanału...", true))
+				event.CreateMessage(travelingMessage)
 				return
 			}
 
 			storesInLocation := make([]*types.NPCStore, 0)
 
 			for _, store := range data.Shops {
-				if loc == store.Location {
+				if store.Location != nil && store.Location.Name == loc.Name {
 					storesInLocation = append(storesInLocation, store)
 				}
 			}
@@ -800,6 +971,640 @@ func commandListener(event *events.ApplicationCommandInteractionCreate) {
 
 			event.CreateMessage(messageBuilder.Build())
 		}
+	case "pojedynek":
+		targetUser := interactionData.User("gracz")
+		targetChar := World.GetPlayer(targetUser.ID.String())
+
+		if targetChar == nil {
+			event.CreateMessage(MessageContent("Użytkownik nie ma postaci", true))
+			return
+		}
+
+		stake, _ := interactionData.OptInt("stawka")
+
+		duelUuid, err := World.ChallengeDuel(playerChar, targetChar, stake)
+
+		if err == nil {
+			content := fmt.Sprintf("<@%s> wyzywa <@%s> na pojedynek!", playerChar.Meta.UserID, targetChar.Meta.UserID)
+
+			if stake > 0 {
+				content += fmt.Sprintf(" Stawka: %d złota", stake)
+			}
+
+			event.CreateMessage(discord.
+				NewMessageCreateBuilder().
+				SetContent(content).
+				AddActionRow(
+					discord.NewSuccessButton("Przyjmij", "duel/accept|"+duelUuid.String()),
+					discord.NewDangerButton("Odrzuć", "duel/decline|"+duelUuid.String()),
+				).
+				Build(),
+			)
+			return
+		}
+
+		event.CreateMessage(MessageContent(DuelErrorMessage(err), true))
+		return
+	case "podróż":
+		rawDestination := strings.SplitN(interactionData.String("cel"), ",", 2)
+
+		if len(rawDestination) != 2 {
+			event.CreateMessage(MessageContent("Nie znaleziono lokacji", true))
+			return
+		}
+
+		destination := types.EntityLocation{Floor: rawDestination[0], Location: rawDestination[1]}
+
+		travelTime, err := playerChar.StartTravel(destination, interactionData.Bool("teleport"))
+
+		if err == nil {
+			if travelTime == 0 {
+				event.CreateMessage(MessageContent(fmt.Sprintf("Przeniesiono do lokacji %s", destination.Location), false))
+			} else {
+				event.CreateMessage(MessageContent(
+					fmt.Sprintf("Wyruszono do lokacji %s, podróż potrwa %d min", destination.Location, travelTime), false,
+				))
+			}
+
+			return
+		}
+
+		msgContent := ""
+
+		switch err.Error() {
+		case "PLAYER_IN_FIGHT":
+			msgContent = "Nie możesz tego zrobić podczas walki"
+		case "PLAYER_DEAD":
+			msgContent = fmt.Sprintf("Nie żyjesz, odrodzisz się za %d min", playerChar.Meta.Respawn)
+		case "ALREADY_TRAVELING":
+			msgContent = "Już jesteś w podróży"
+		case "LOCATION_NOT_FOUND":
+			msgContent = "Nie znaleziono lokacji"
+		case "SAME_LOCATION":
+			msgContent = "Już jesteś w tej lokacji"
+		case "FLOOR_LOCKED":
+			msgContent = "To piętro nie zostało jeszcze odblokowane"
+		case "NOT_CONNECTED":
+			msgContent = "Nie da się tam dojść z tej lokacji"
+		case "NOT_TP_LOCATION":
+			msgContent = "Teleportacja działa tylko między punktami teleportacji"
+		case "NOT_ENOUGH_GOLD":
+			msgContent = fmt.Sprintf("Za mało pieniędzy (teleportacja kosztuje %d)", data.WorldConfig.TeleportCost)
+		default:
+			msgContent = "Nieznany błąd (podróż)"
+		}
+
+		event.CreateMessage(MessageContent(msgContent, true))
+		return
+	case "questy":
+		switch *interactionData.SubCommandName {
+		case "lista":
+			if len(playerChar.Quests.Active) == 0 {
+				event.CreateMessage(MessageContent(
+					fmt.Sprintf("Nie masz aktywnych zadań (ukończone: %d)", len(playerChar.Quests.Completed)), true,
+				))
+				return
+			}
+
+			embed := discord.NewEmbedBuilder().
+				SetTitle("Zadania").
+				SetFooterTextf("Ukończone: %d", len(playerChar.Quests.Completed))
+
+			for questUuid := range playerChar.Quests.Active {
+				quest, exists := data.Quests[questUuid]
+
+				if !exists || len(embed.Fields) >= 25 {
+					continue
+				}
+
+				title := quest.Name
+
+				if playerChar.IsQuestDone(quest) {
+					title += " ✅"
+				}
+
+				embed.AddField(title, QuestSummary(quest, playerChar), false)
+			}
+
+			event.CreateMessage(MessageEmbed(embed.Build()))
+			return
+		case "dostępne":
+			loc := playerChar.GetLocation()
+
+			if loc == nil {
+				event.CreateMessage(travelingMessage)
+				return
+			}
+
+			embed := discord.NewEmbedBuilder().SetTitle("Dostępne zadania")
+			buttons := make([]discord.InteractiveComponent, 0)
+
+			for _, quest := range data.Quests {
+				if quest.Location != loc.Name || len(buttons) >= 5 {
+					continue
+				}
+
+				if _, active := playerChar.Quests.Active[quest.UUID]; active || playerChar.Quests.HasCompleted(quest.UUID) {
+					continue
+				}
+
+				embed.AddField(fmt.Sprintf("%s - %s", quest.Giver, quest.Name), QuestSummary(quest, playerChar), false)
+
+				buttons = append(buttons, discord.NewPrimaryButton(
+					fmt.Sprintf("Przyjmij: %s", quest.Name), "quest/accept|"+quest.UUID.String(),
+				))
+			}
+
+			if len(buttons) == 0 {
+				event.CreateMessage(MessageContent("Nikt tu nie ma dla ciebie zadań", true))
+				return
+			}
+
+			event.CreateMessage(discord.
+				NewMessageCreateBuilder().
+				AddEmbeds(embed.Build()).
+				AddActionRow(buttons...).
+				SetEphemeral(true).
+				Build(),
+			)
+			return
+		case "oddaj":
+			questUuid, err := uuid.Parse(interactionData.String("zadanie"))
+
+			if err != nil {
+				event.CreateMessage(MessageContent("Nie znaleziono zadania", true))
+				return
+			}
+
+			quest, exists := data.Quests[questUuid]
+
+			if !exists {
+				event.CreateMessage(MessageContent("Nie znaleziono zadania", true))
+				return
+			}
+
+			loc := playerChar.GetLocation()
+
+			if loc == nil || loc.Name != quest.Location {
+				event.CreateMessage(MessageContent(
+					fmt.Sprintf("%s czeka na ciebie w lokacji %s", quest.Giver, quest.Location), true,
+				))
+				return
+			}
+
+			err = playerChar.CompleteQuest(quest)
+
+			if err == nil {
+				event.CreateMessage(MessageContent(fmt.Sprintf("Ukończono zadanie %s!", quest.Name), false))
+				return
+			}
+
+			msgContent := ""
+
+			switch err.Error() {
+			case "QUEST_NOT_ACTIVE":
+				msgContent = "Nie masz takiego zadania"
+			case "QUEST_NOT_DONE":
+				msgContent = "Zadanie nie jest jeszcze ukończone"
+			case "NOT_ENOUGH_ITEMS":
+				msgContent = "Brakuje ci przedmiotów"
+			default:
+				msgContent = "Nieznany błąd (zadania)"
+			}
+
+			event.CreateMessage(MessageContent(msgContent, true))
+			return
+		}
+	case "craft":
+		switch *interactionData.SubCommandName {
+		case "lista":
+			if len(data.Recipes) == 0 {
+				event.CreateMessage(MessageContent("Brak przepisów", true))
+				return
+			}
+
+			recipes := make([]types.Recipe, 0)
+
+			for _, recipe := range data.Recipes {
+				recipes = append(recipes, recipe)
+			}
+
+			slices.SortFunc(recipes, func(i, j types.Recipe) int {
+				return strings.Compare(i.Name, j.Name)
+			})
+
+			embed := discord.NewEmbedBuilder().SetTitle("Przepisy")
+
+			for _, recipe := range recipes {
+				if len(embed.Fields) >= 25 {
+					break
+				}
+
+				embed.AddField(recipe.Name, RecipeSummary(recipe, playerChar), false)
+			}
+
+			event.CreateMessage(MessageEmbed(embed.Build()))
+			return
+		case "wytwórz":
+			recipeUuid, err := uuid.Parse(interactionData.String("przepis"))
+
+			if err != nil {
+				event.CreateMessage(MessageContent("Nie znaleziono przepisu", true))
+				return
+			}
+
+			recipe, exists := data.Recipes[recipeUuid]
+
+			if !exists {
+				event.CreateMessage(MessageContent("Nie znaleziono przepisu", true))
+				return
+			}
+
+			loc := playerChar.GetLocation()
+
+			crafted, err := playerChar.Craft(recipe, loc)
+
+			if err == nil {
+				embed := discord.NewEmbedBuilder().SetTitle("Wytworzono przedmiot")
+				bestRarity := types.RARITY_COMMON
+
+				for _, item := range crafted {
+					itemDescription := ItemAffixSummary(item)
+
+					if itemDescription == "" {
+						itemDescription = item.Description
+					}
+
+					if item.Rarity > bestRarity {
+						bestRarity = item.Rarity
+					}
+
+					embed.AddField(fmt.Sprintf("%dx %s", item.Count, item.DisplayName()), itemDescription, false)
+				}
+
+				embed.SetColor(types.RarityToColor[bestRarity])
+
+				event.CreateMessage(MessageEmbed(embed.Build()))
+				return
+			}
+
+			msgContent := ""
+
+			switch err.Error() {
+			case "PLAYER_IN_FIGHT":
+				msgContent = "Nie możesz tego zrobić podczas walki"
+			case "WRONG_LOCATION":
+				msgContent = "Nie możesz tego wytworzyć w tej lokacji"
+			case "NOT_ENOUGH_GOLD":
+				msgContent = "Za mało pieniędzy"
+			case "NOT_ENOUGH_ITEMS":
+				msgContent = "Brakuje ci materiałów"
+			default:
+				msgContent = "Nieznany błąd (wytwarzanie)"
+			}
+
+			event.CreateMessage(MessageContent(msgContent, true))
+			return
+		}
+	case "furia":
+		if playerChar.Fury == nil {
+			event.CreateMessage(MessageContent("Nie masz furii", true))
+			return
+		}
+
+		furyObj := playerChar.Fury
+
+		switch *interactionData.SubCommandName {
+		case "pokaż":
+			statsText := ""
+
+			for stat, value := range furyObj.GetStats() {
+				statsText += fmt.Sprintf("%s: %d\n", types.StatToString[stat], value)
+			}
+
+			if statsText == "" {
+				statsText = "Brak"
+			}
+
+			skillsText := ""
+
+			for _, skill := range furyObj.GetSkills() {
+				skillsText += fmt.Sprintf("**%s** - %s\n", skill.GetName(), skill.GetDescription())
+			}
+
+			if skillsText == "" {
+				skillsText = "Brak"
+			}
+
+			lvlText := fmt.Sprintf("%d %d/%d", furyObj.XP.LVL, furyObj.XP.XP, furyObj.NextLvlXPGauge())
+
+			if furyObj.XP.LVL >= 10 {
+				lvlText = "10 MAX"
+			}
+
+			embed := discord.NewEmbedBuilder().
+				SetTitle(furyObj.Name).
+				SetDescription(data.Furies[furyObj.UUID].Description).
+				AddField("Poziom zaawansowania", fmt.Sprintf("%d/%d", furyObj.CurrentTier, len(furyObj.Tiers)), true).
+				AddField("Lvl", lvlText, true).
+				AddField("Statystyki", strings.TrimSuffix(statsText, "\n"), false).
+				AddField("Umiejętności", strings.TrimSuffix(skillsText, "\n"), false)
+
+			if nextTier := furyObj.GetNextTier(); nextTier != nil {
+				embed.AddField("Wymagania awansu", FuryRequirementsText(playerChar, nextTier), false)
+			}
+
+			event.CreateMessage(MessageEmbed(embed.Build()))
+			return
+		case "awansuj":
+			err := playerChar.TierUpFury()
+
+			if err == nil {
+				event.CreateMessage(MessageContent(
+					fmt.Sprintf("%s osiąga %d poziom zaawansowania!", furyObj.Name, furyObj.CurrentTier), false,
+				))
+				return
+			}
+
+			msgContent := ""
+
+			switch err.Error() {
+			case "PLAYER_IN_FIGHT":
+				msgContent = "Nie możesz tego zrobić podczas walki"
+			case "MAX_TIER":
+				msgContent = "Furia osiągnęła już najwyższy poziom zaawansowania"
+			case "REQUIREMENTS_NOT_MET", "NOT_ENOUGH_ITEMS":
+				msgContent = "Nie spełniasz wymagań awansu"
+			default:
+				msgContent = "Nieznany błąd (furia)"
+			}
+
+			event.CreateMessage(MessageContent(msgContent, true))
+			return
+		}
+	case "gildia":
+		var err error
+
+		switch *interactionData.SubCommandName {
+		case "załóż":
+			_, err = World.CreateGuild(playerChar, interactionData.String("nazwa"))
+
+			if err == nil {
+				event.CreateMessage(MessageContent(
+					fmt.Sprintf("Założono gildię %s", strings.TrimSpace(interactionData.String("nazwa"))), false,
+				))
+				return
+			}
+		case "pokaż":
+			var guildObj *guild.Guild
+
+			_, guildObj, err = World.GetPlayerGuild(playerChar)
+
+			if err == nil {
+				event.CreateMessage(MessageEmbed(GuildEmbed(guildObj)))
+				return
+			}
+		case "zaproś":
+			mentionedUser := interactionData.User("gracz")
+
+			pl := World.GetPlayer(mentionedUser.ID.String())
+
+			if pl == nil {
+				event.CreateMessage(MessageContent("Użytkownik nie ma postaci", true))
+				return
+			}
+
+			var guildUuid uuid.UUID
+
+			guildUuid, err = World.InviteToGuild(playerChar, pl)
+
+			if err == nil {
+				ch, error := event.Client().Rest().CreateDMChannel(mentionedUser.ID)
+
+				if error == nil {
+					_, error = event.Client().Rest().CreateMessage(ch.ID(), discord.NewMessageCreateBuilder().
+						SetContentf(
+							"<@%s> (%s) zaprasza cię do gildii %s, zaproszenie wygasa za %d minut",
+							user.ID.String(), playerChar.GetName(), World.Guilds[guildUuid].Name, data.GuildConfig.InviteTime,
+						).
+						AddActionRow(
+							discord.NewPrimaryButton("Akceptuj", "guild/res|"+guildUuid.String()),
+							discord.NewDangerButton("Odrzuć", "guild/rej|"+guildUuid.String()),
+						).
+						Build(),
+					)
+				}
+
+				if error != nil {
+					World.RejectGuildInvite(guildUuid, pl)
+
+					event.CreateMessage(MessageContent("Nie można wysłać wiadomości do gracza", true))
+					return
+				}
+
+				event.CreateMessage(MessageContent("Wysłano zaproszenie do gildii", true))
+				return
+			}
+		case "awansuj", "degraduj":
+			pl := World.GetPlayer(interactionData.User("gracz").ID.String())
+
+			if pl == nil {
+				event.CreateMessage(MessageContent("Użytkownik nie ma postaci", true))
+				return
+			}
+
+			var rank guild.GuildRank
+
+			if *interactionData.SubCommandName == "awansuj" {
+				rank, err = World.PromoteGuildMember(playerChar, pl)
+			} else {
+				rank, err = World.DemoteGuildMember(playerChar, pl)
+			}
+
+			if err == nil {
+				event.CreateMessage(MessageContent(
+					fmt.Sprintf("%s ma teraz rangę %s", pl.GetName(), guild.RankToString[rank]), false,
+				))
+				return
+			}
+		case "wyrzuć":
+			pl := World.GetPlayer(interactionData.User("gracz").ID.String())
+
+			if pl == nil {
+				event.CreateMessage(MessageContent("Użytkownik nie ma postaci", true))
+				return
+			}
+
+			err = World.KickFromGuild(playerChar, pl)
+
+			if err == nil {
+				event.CreateMessage(MessageContent("Wyrzucono gracza z gildii", true))
+				return
+			}
+		case "opuść":
+			err = World.LeaveGuild(playerChar)
+
+			if err == nil {
+				event.CreateMessage(MessageContent("Opuściłeś gildię", true))
+				return
+			}
+		case "wpłać", "wypłać":
+			deposit := *interactionData.SubCommandName == "wpłać"
+			gold, hasGold := interactionData.OptInt("złoto")
+			rawItem, hasItem := interactionData.OptString("przedmiot")
+
+			if !hasGold && !hasItem {
+				event.CreateMessage(MessageContent("Podaj złoto lub przedmiot", true))
+				return
+			}
+
+			if hasGold {
+				if deposit {
+					err = World.DepositGuildGold(playerChar, gold)
+				} else {
+					err = World.WithdrawGuildGold(playerChar, gold)
+				}
+			}
+
+			if err == nil && hasItem {
+				itemUuid, parseErr := uuid.Parse(rawItem)
+
+				if parseErr != nil {
+					event.CreateMessage(MessageContent("Nie ma takiego przedmiotu", true))
+					return
+				}
+
+				count, hasCount := interactionData.OptInt("ilość")
+
+				if !hasCount {
+					count = 1
+				}
+
+				if deposit {
+					err = World.DepositGuildItem(playerChar, itemUuid, count)
+				} else {
+					err = World.WithdrawGuildItem(playerChar, itemUuid, count)
+				}
+			}
+
+			if err == nil {
+				if deposit {
+					event.CreateMessage(MessageContent("Wpłacono do banku gildii", true))
+				} else {
+					event.CreateMessage(MessageContent("Wypłacono z banku gildii", true))
+				}
+				return
+			}
+		}
+
+		msgContent := ""
+
+		switch err.Error() {
+		case "NOT_IN_GUILD":
+			msgContent = "Nie jesteś w gildii"
+		case "ALREADY_IN_GUILD":
+			msgContent = "Gracz jest już w gildii"
+		case "INVALID_NAME":
+			msgContent = fmt.Sprintf("Nazwa gildii musi mieć od 1 do %d znaków", world.GuildNameMaxLength)
+		case "NAME_TAKEN":
+			msgContent = "Gildia o takiej nazwie już istnieje"
+		case "NOT_ENOUGH_GOLD":
+			msgContent = "Za mało złota"
+		case "NO_PERMISSION":
+			msgContent = "Nie masz uprawnień"
+		case "GUILD_FULL":
+			msgContent = "Gildia jest pełna"
+		case "SELF_TARGET":
+			msgContent = "Nie możesz tego zrobić samemu sobie"
+		case "NOT_SAME_GUILD":
+			msgContent = "Gracz nie jest w twojej gildii"
+		case "MIN_RANK":
+			msgContent = "Gracz ma już najniższą rangę"
+		case "LEADER_CANNOT_LEAVE":
+			msgContent = "Lider musi najpierw przekazać przywództwo"
+		case "INVALID_AMOUNT":
+			msgContent = "Nieprawidłowa ilość"
+		case "ITEM_NOT_FOUND":
+			msgContent = "Nie ma takiego przedmiotu"
+		case "ITEM_NOT_STACKABLE":
+			msgContent = "Do banku można wpłacać tylko przedmioty, które się kumulują"
+		case "NOT_ENOUGH_ITEMS":
+			msgContent = "Za mało przedmiotów"
+		default:
+			msgContent = "Nieznany błąd (gildia)"
+		}
+
+		event.CreateMessage(MessageContent(msgContent, true))
+		return
+	case "cmentarz":
+		switch *interactionData.SubCommandName {
+		case "lista":
+			if len(World.Graveyard) == 0 {
+				event.CreateMessage(MessageContent("Cmentarz jest pusty", true))
+				return
+			}
+
+			pageCount := (len(World.Graveyard) + GraveyardPageSize - 1) / GraveyardPageSize
+			page, isPagePresent := interactionData.OptInt("strona")
+
+			if !isPagePresent {
+				page = 1
+			}
+
+			if page < 1 || page > pageCount {
+				event.CreateMessage(MessageContent(fmt.Sprintf("Cmentarz ma %d stron", pageCount), true))
+				return
+			}
+
+			pageStart := (page - 1) * GraveyardPageSize
+			pageEnd := min(page*GraveyardPageSize, len(World.Graveyard))
+
+			graveyardText := ""
+
+			for idx, fallen := range World.Graveyard[pageStart:pageEnd] {
+				graveyardText += fmt.Sprintf(
+					"%d. %s (<@%s>) - poziom %d, %s\n%s\n",
+					pageStart+idx+1, fallen.Name, fallen.UserID, fallen.Level, fallen.Date.Format("02.01.2006"), fallen.Cause,
+				)
+			}
+
+			event.CreateMessage(
+				MessageEmbed(
+					discord.NewEmbedBuilder().
+						SetTitle("Cmentarz").
+						SetDescription(strings.TrimSuffix(graveyardText, "\n")).
+						SetFooterTextf("Strona %d/%d", page, pageCount).
+						Build(),
+				),
+			)
+			return
+		case "przywróć":
+			if !isAdmin(member) {
+				event.CreateMessage(MessageContent("Nie masz uprawnień do tej komendy", true))
+				return
+			}
+
+			restored, err := World.RestoreFallen(interactionData.Int("numer") - 1)
+
+			if err == nil {
+				event.CreateMessage(MessageContent("Przywrócono postać "+restored.GetName(), false))
+				return
+			}
+
+			msgContent := ""
+
+			switch err.Error() {
+			case "FALLEN_NOT_FOUND":
+				msgContent = "Nie znaleziono postaci o tym numerze"
+			case "USER_HAS_CHARACTER":
+				msgContent = "Gracz ma już postać"
+			default:
+				msgContent = "Nieznany błąd (cmentarz)"
+			}
+
+			event.CreateMessage(MessageContent(msgContent, true))
+			return
+		}
 	case "turniej":
 		switch *interactionData.SubCommandName {
 		case "stwórz":
@@ -814,11 +1619,48 @@ func commandListener(event *events.ApplicationCommandInteractionCreate) {
 				maxCount = -1
 			}
 
+			format, _ := interactionData.OptInt("format")
+			teamSize, _ := interactionData.OptInt("drużyny")
+
+			if teamSize < 0 || teamSize > data.PartyConfig.MaxSize {
+				event.CreateMessage(MessageContent(fmt.Sprintf("Drużyna może mieć od 1 do %v graczy", data.PartyConfig.MaxSize), true))
+				return
+			}
+
+			var startTime *time.Time
+
+			if startIn, isStartPresent := interactionData.OptInt("za"); isStartPresent {
+				if startIn <= 0 {
+					event.CreateMessage(MessageContent("Czas do startu musi być dodatni", true))
+					return
+				}
+
+				parsedTime := time.Now().Add(time.Duration(startIn) * time.Minute)
+				startTime = &parsedTime
+			}
+
+			prizePool := data.TournamentConfig.PrizePools[0].Name
+
+			if poolName, isPoolPresent := interactionData.OptString("nagrody"); isPoolPresent {
+				if data.TournamentConfig.GetPrizePool(poolName) == nil {
+					event.CreateMessage(MessageContent("Nie znaleziono puli nagród", true))
+					return
+				}
+
+				prizePool = poolName
+			}
+
 			tournament := tournament.Tournament{
 				Uuid:         uuid.New(),
 				Name:         interactionData.String("nazwa"),
 				MaxPlayers:   maxCount,
+				Format:       tournament.TournamentFormat(format),
+				TeamSize:     teamSize,
+				Teams:        make(map[uuid.UUID]*tournament.TournamentTeam),
 				Participants: make([]uuid.UUID, 0),
+				StartTime:    startTime,
+				CheckedIn:    make(map[uuid.UUID]bool),
+				PrizePool:    prizePool,
 			}
 
 			World.RegisterTournament(tournament)
@@ -855,6 +1697,110 @@ func commandListener(event *events.ApplicationCommandInteractionCreate) {
 
 			event.CreateMessage(MessageContent("Rozpoczynam turniej", true))
 			return
+		case "drabinka":
+			tournamentName := interactionData.String("nazwa")
+
+			var actualTournament *tournament.Tournament
+
+			for _, t := range World.Tournaments {
+				if t.Name == tournamentName {
+					actualTournament = t
+					break
+				}
+			}
+
+			if actualTournament == nil || actualTournament.State != tournament.Running {
+				event.CreateMessage(MessageContent("Nie znaleziono trwającego turnieju", true))
+				return
+			}
+
+			bracket := World.BracketFile(actualTournament)
+
+			if bracket == nil {
+				event.CreateMessage(MessageContent("Nie udało się narysować drabinki", true))
+				return
+			}
+
+			event.CreateMessage(discord.NewMessageCreateBuilder().AddFiles(bracket).Build())
+			return
+		case "zakład":
+			if playerChar == nil {
+				event.CreateMessage(MessageContent("Nie masz postaci", true))
+				return
+			}
+
+			tournamentName := interactionData.String("nazwa")
+
+			var actualTournament *tournament.Tournament
+
+			for _, t := range World.Tournaments {
+				if t.Name == tournamentName {
+					actualTournament = t
+					break
+				}
+			}
+
+			if actualTournament == nil {
+				event.CreateMessage(MessageContent("Nie znaleziono turnieju", true))
+				return
+			}
+
+			var matchIdx, side int
+
+			if _, err := fmt.Sscanf(interactionData.String("typ"), "%d|%d", &matchIdx, &side); err != nil {
+				event.CreateMessage(MessageContent("Wybierz mecz z listy", true))
+				return
+			}
+
+			bet, err := World.PlaceBet(actualTournament.Uuid, matchIdx, side, playerChar, interactionData.Int("kwota"))
+
+			if err == nil {
+				event.CreateMessage(MessageContent(
+					fmt.Sprintf("Postawiono %v złota po kursie %v, możliwa wygrana: %v złota", bet.Amount, tournament.OddsToString(bet.Odds), bet.Amount*bet.Odds/100),
+					true,
+				))
+				return
+			}
+
+			msgContent := ""
+
+			switch err.Error() {
+			case "TOURNAMENT_NOT_FOUND":
+				msgContent = "Nie znaleziono turnieju"
+			case "TOURNAMENT_NOT_RUNNING":
+				msgContent = "Turniej jeszcze się nie rozpoczął"
+			case "MATCH_NOT_FOUND":
+				msgContent = "Nie znaleziono meczu"
+			case "MATCH_STARTED":
+				msgContent = "Ten mecz już się rozpoczął"
+			case "PARTICIPANT_CANNOT_BET":
+				msgContent = "Uczestnicy turnieju nie mogą obstawiać"
+			case "ALREADY_BET":
+				msgContent = "Już obstawiasz ten mecz"
+			case "BET_TOO_LOW":
+				msgContent = fmt.Sprintf("Minimalna stawka to %v złota", data.TournamentConfig.BetMin)
+			case "BET_TOO_HIGH":
+				msgContent = fmt.Sprintf("Maksymalna stawka to %v złota", data.TournamentConfig.BetMax)
+			case "NOT_ENOUGH_GOLD":
+				msgContent = "Nie masz wystarczająco złota"
+			default:
+				msgContent = "Nieznany błąd (zakład)"
+			}
+
+			event.CreateMessage(MessageContent(msgContent, true))
+			return
+		case "historia":
+			if mentionedUser, exists := interactionData.OptUser("gracz"); exists {
+				playerChar = World.GetPlayer(mentionedUser.ID.String())
+
+				if playerChar == nil {
+					event.CreateMessage(MessageContent("Użytkownik nie ma postaci", true))
+					return
+				}
+			}
+
+			event.CreateMessage(MessageEmbed(TournamentHistoryEmbed(playerChar)))
+			return
 		}
 	}
 }
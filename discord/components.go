@@ -3,9 +3,8 @@ package discord
 import (
 	"fmt"
 	"sao/data"
-	"sao/player"
 	"sao/types"
-	"sao/world/party"
+	"sao/world"
 	"strconv"
 	"strings"
 
@@ -16,6 +15,9 @@ import (
 )
 
 func ModalSubmitHandler(event *events.ModalSubmitInteractionCreate) {
+	World.Mutex.Lock()
+	defer World.Mutex.Unlock()
+
 	if event.Data.CustomID != "shop/buy" {
 		return
 	}
@@ -173,7 +175,357 @@ func HandleSkillUnlock(event *events.ComponentInteractionCreate) {
 	return
 }
 
+func HandleItemUsage(event *events.ComponentInteractionCreate) {
+	pl := World.GetPlayer(event.User().ID.String())
+
+	if pl == nil {
+		event.CreateMessage(noCharMessage)
+		return
+	}
+
+	itemUuid, err := uuid.Parse(event.StringSelectMenuInteractionData().Values[0])
+
+	if err != nil {
+		event.CreateMessage(unknownError)
+		return
+	}
+
+	item := pl.Inventory.GetItem(itemUuid)
+
+	err = pl.UseItemOutOfFight(itemUuid)
+
+	if err == nil {
+		event.UpdateMessage(discord.
+			NewMessageUpdateBuilder().
+			SetContentf("Użyto przedmiotu %s", item.Name).
+			ClearContainerComponents().
+			Build(),
+		)
+
+		return
+	}
+
+	msgContent := ""
+
+	switch err.Error() {
+	case "PLAYER_IN_FIGHT":
+		msgContent = "Nie możesz tego zrobić podczas walki"
+	case "ITEM_NOT_FOUND":
+		msgContent = "Nie masz takiego przedmiotu"
+	case "ITEM_NOT_USABLE":
+		msgContent = "Nie można użyć tego przedmiotu poza walką"
+	case "FURY_ALREADY_BOUND":
+		msgContent = "Masz już furię"
+	case "FURY_NOT_FOUND":
+		msgContent = "Ta furia już nie istnieje"
+	default:
+		msgContent = "Nieznany błąd (przedmiot)"
+	}
+
+	event.CreateMessage(MessageContent(msgContent, true))
+}
+
+func HandleRespec(event *events.ComponentInteractionCreate) {
+	if event.ComponentInteraction.Data.CustomID() == "respec/cancel" {
+		event.UpdateMessage(discord.
+			NewMessageUpdateBuilder().
+			SetContent("Anulowano reset").
+			ClearContainerComponents().
+			Build(),
+		)
+
+		return
+	}
+
+	pl := World.GetPlayer(event.User().ID.String())
+
+	if pl == nil {
+		event.CreateMessage(noCharMessage)
+		return
+	}
+
+	loc := pl.GetLocation()
+
+	refunded, err := World.RespecPlayer(pl, loc)
+
+	if err == nil {
+		event.UpdateMessage(discord.
+			NewMessageUpdateBuilder().
+			SetContentf("Zresetowano umiejętności, odzyskano %d punktów akcji", refunded).
+			ClearContainerComponents().
+			Build(),
+		)
+
+		return
+	}
+
+	msgContent := ""
+
+	switch err.Error() {
+	case "PLAYER_IN_FIGHT":
+		msgContent = "Nie możesz tego zrobić podczas walki"
+	case "WRONG_LOCATION":
+		msgContent = "Reset umiejętności jest dostępny tylko w mieście"
+	case "NOTHING_TO_RESET":
+		msgContent = "Nie masz nic do zresetowania"
+	case "NOT_ENOUGH_GOLD":
+		msgContent = "Za mało pieniędzy"
+	default:
+		msgContent = "Nieznany błąd (reset)"
+	}
+
+	event.CreateMessage(MessageContent(msgContent, true))
+}
+
+func HandleQuestAccept(event *events.ComponentInteractionCreate) {
+	pl := World.GetPlayer(event.User().ID.String())
+
+	if pl == nil {
+		event.CreateMessage(noCharMessage)
+		return
+	}
+
+	questUuid, err := uuid.Parse(strings.Split(event.ComponentInteraction.Data.CustomID(), "|")[1])
+
+	if err != nil {
+		event.CreateMessage(unknownError)
+		return
+	}
+
+	quest, exists := data.Quests[questUuid]
+
+	if !exists {
+		event.CreateMessage(MessageContent("Nie znaleziono zadania", true))
+		return
+	}
+
+	err = pl.AcceptQuest(quest, pl.GetLocation())
+
+	if err == nil {
+		event.CreateMessage(MessageContent(fmt.Sprintf("Przyjęto zadanie %s", quest.Name), true))
+		return
+	}
+
+	msgContent := ""
+
+	switch err.Error() {
+	case "QUEST_ALREADY_ACTIVE":
+		msgContent = "Już masz to zadanie"
+	case "QUEST_ALREADY_COMPLETED":
+		msgContent = "To zadanie zostało już ukończone"
+	case "PLAYER_LVL_TOO_LOW":
+		msgContent = "Masz za niski poziom"
+	case "WRONG_LOCATION":
+		msgContent = fmt.Sprintf("%s czeka na ciebie w lokacji %s", quest.Giver, quest.Location)
+	default:
+		msgContent = "Nieznany błąd (zadania)"
+	}
+
+	event.CreateMessage(MessageContent(msgContent, true))
+}
+
+func HandleWorldBossJoin(event *events.ComponentInteractionCreate) {
+	pl := World.GetPlayer(event.User().ID.String())
+
+	if pl == nil {
+		event.CreateMessage(noCharMessage)
+		return
+	}
+
+	bossUuid, err := uuid.Parse(strings.Split(event.ComponentInteraction.Data.CustomID(), "|")[1])
+
+	if err != nil {
+		event.CreateMessage(unknownError)
+		return
+	}
+
+	joined, err := World.JoinWorldBoss(pl, bossUuid)
+
+	if err == nil {
+		names := make([]string, 0)
+
+		for _, member := range joined {
+			names = append(names, member.GetName())
+		}
+
+		event.CreateMessage(MessageContent(
+			fmt.Sprintf("Do walki z %s dołączają: %s", data.WorldBosses[bossUuid].Name, strings.Join(names, ", ")), false,
+		))
+		return
+	}
+
+	msgContent := ""
+
+	switch err.Error() {
+	case "BOSS_NOT_ACTIVE":
+		msgContent = "Ten boss już nie czeka na śmiałków"
+	case "WRONG_LOCATION":
+		msgContent = "Musisz być w lokacji bossa"
+	case "PLAYER_IN_FIGHT":
+		msgContent = "Nie możesz tego zrobić podczas walki"
+	case "PLAYER_DEAD":
+		msgContent = "Twoja postać nie żyje"
+	case "ALREADY_JOINED":
+		msgContent = "Już dołączyłeś do walki"
+	default:
+		msgContent = "Nieznany błąd (boss)"
+	}
+
+	event.CreateMessage(MessageContent(msgContent, true))
+}
+
+func HandleDuel(event *events.ComponentInteractionCreate) {
+	segments := strings.Split(event.ComponentInteraction.Data.CustomID(), "|")
+	duelUuid, err := uuid.Parse(segments[1])
+
+	if err != nil {
+		event.CreateMessage(unknownError)
+		return
+	}
+
+	duel, exists := World.Duels[duelUuid]
+
+	if !exists {
+		event.CreateMessage(MessageContent("Pojedynek nie istnieje", true))
+		return
+	}
+
+	pl := World.GetPlayer(event.User().ID.String())
+
+	if pl == nil || pl.GetUUID() != duel.Target {
+		event.CreateMessage(MessageContent("To nie twój pojedynek", true))
+		return
+	}
+
+	if strings.HasPrefix(segments[0], "duel/decline") {
+		World.DeclineDuel(duelUuid)
+
+		event.UpdateMessage(discord.
+			NewMessageUpdateBuilder().
+			SetContentf("%s odrzuca pojedynek", pl.GetName()).
+			ClearContainerComponents().
+			Build(),
+		)
+		return
+	}
+
+	if err := World.AcceptDuel(duelUuid); err != nil {
+		event.UpdateMessage(discord.
+			NewMessageUpdateBuilder().
+			SetContent(DuelErrorMessage(err)).
+			ClearContainerComponents().
+			Build(),
+		)
+		return
+	}
+
+	event.UpdateMessage(discord.
+		NewMessageUpdateBuilder().
+		SetContentf("%s przyjmuje pojedynek!", pl.GetName()).
+		ClearContainerComponents().
+		Build(),
+	)
+}
+
+func HandleLootRoll(event *events.ComponentInteractionCreate) {
+	segments := strings.Split(event.ComponentInteraction.Data.CustomID(), "|")
+	rollUuid, err := uuid.Parse(segments[1])
+
+	if err != nil {
+		event.CreateMessage(unknownError)
+		return
+	}
+
+	pl := World.GetPlayer(event.User().ID.String())
+
+	if pl == nil {
+		event.CreateMessage(noCharMessage)
+		return
+	}
+
+	choice := world.LootPass
+	choiceText := "Pasujesz"
+
+	switch segments[0] {
+	case "roll/need":
+		choice = world.LootNeed
+		choiceText = "Potrzebujesz tego przedmiotu"
+	case "roll/greed":
+		choice = world.LootGreed
+		choiceText = "Chcesz tego przedmiotu"
+	}
+
+	err = World.ChooseLoot(rollUuid, pl.GetUUID(), choice)
+
+	if err == nil {
+		event.CreateMessage(MessageContent(choiceText, true))
+		return
+	}
+
+	msgContent := ""
+
+	switch err.Error() {
+	case "ROLL_NOT_FOUND":
+		msgContent = "Podział tego przedmiotu już się zakończył"
+	case "NOT_ELIGIBLE":
+		msgContent = "Nie bierzesz udziału w podziale"
+	case "ALREADY_CHOSEN":
+		msgContent = "Już dokonałeś wyboru"
+	default:
+		msgContent = "Nieznany błąd (łupy)"
+	}
+
+	event.CreateMessage(MessageContent(msgContent, true))
+}
+
+func HandleReadyCheck(event *events.ComponentInteractionCreate) {
+	segments := strings.Split(event.ComponentInteraction.Data.CustomID(), "|")
+	partyUuid, err := uuid.Parse(segments[1])
+
+	if err != nil {
+		event.CreateMessage(unknownError)
+		return
+	}
+
+	pl := World.GetPlayer(event.User().ID.String())
+
+	if pl == nil {
+		event.CreateMessage(noCharMessage)
+		return
+	}
+
+	ready := segments[0] == "ready/yes"
+
+	err = World.AnswerReadyCheck(partyUuid, pl.GetUUID(), ready)
+
+	if err == nil {
+		if ready {
+			event.CreateMessage(MessageContent(pl.GetName()+" jest gotowy", false))
+		} else {
+			event.CreateMessage(MessageContent(pl.GetName()+" nie dołącza do walki", false))
+		}
+
+		return
+	}
+
+	msgContent := ""
+
+	switch err.Error() {
+	case "CHECK_NOT_FOUND":
+		msgContent = "Sprawdzanie gotowości już się zakończyło"
+	case "NOT_PENDING":
+		msgContent = "Nie bierzesz udziału w tym sprawdzaniu gotowości"
+	default:
+		msgContent = "Nieznany błąd (gotowość)"
+	}
+
+	event.CreateMessage(MessageContent(msgContent, true))
+}
+
 func ComponentHandler(event *events.ComponentInteractionCreate) {
+	World.Mutex.Lock()
+	defer World.Mutex.Unlock()
+
 	customId := event.ComponentInteraction.Data.CustomID()
 
 	if customId == "utils|wait" {
@@ -186,6 +538,41 @@ func ComponentHandler(event *events.ComponentInteractionCreate) {
 		return
 	}
 
+	if customId == "inv/use" {
+		HandleItemUsage(event)
+		return
+	}
+
+	if strings.HasPrefix(customId, "respec/") {
+		HandleRespec(event)
+		return
+	}
+
+	if strings.HasPrefix(customId, "quest/accept") {
+		HandleQuestAccept(event)
+		return
+	}
+
+	if strings.HasPrefix(customId, "boss/join") {
+		HandleWorldBossJoin(event)
+		return
+	}
+
+	if strings.HasPrefix(customId, "duel/") {
+		HandleDuel(event)
+		return
+	}
+
+	if strings.HasPrefix(customId, "roll/") {
+		HandleLootRoll(event)
+		return
+	}
+
+	if strings.HasPrefix(customId, "ready/") {
+		HandleReadyCheck(event)
+		return
+	}
+
 	if strings.HasPrefix(customId, "chc/") {
 		HandleChoice(event, strings.TrimPrefix(customId, "chc/"))
 		return
@@ -202,42 +589,13 @@ func ComponentHandler(event *events.ComponentInteractionCreate) {
 	}
 
 	if strings.HasPrefix(customId, "party") {
-		if strings.HasPrefix(customId, "party/res") {
-			pl := World.GetPlayer(event.User().ID.String())
-			partyUuid := uuid.MustParse(strings.Split(customId, "|")[1])
-
-			if pl.Meta.Party != nil {
-				event.CreateMessage(alreadyInParty)
-				return
-			}
-
-			World.Parties[partyUuid].Players = append(World.Parties[partyUuid].Players, &party.PartyEntry{
-				PlayerUuid: pl.GetUUID(),
-				Role:       party.None,
-			})
-
-			for _, player := range World.Parties[partyUuid].Players {
-				World.Players[player.PlayerUuid].Meta.Party.MembersCount = len(World.Parties[partyUuid].Players)
-			}
-
-			pl.Meta.Party = &player.PartialParty{
-				UUID:         partyUuid,
-				Role:         party.None,
-				MembersCount: len(World.Parties[partyUuid].Players),
-			}
-
-			event.CreateMessage(MessageContent("Dołączono do party", true))
-			return
-		}
+		HandlePartyInvite(event)
+		return
+	}
 
-		if customId == "party/rej" {
-			event.UpdateMessage(discord.
-				NewMessageUpdateBuilder().
-				ClearContainerComponents().
-				SetContent("Odrzucono zaproszenie").
-				Build(),
-			)
-		}
+	if strings.HasPrefix(customId, "guild/") {
+		HandleGuildInvite(event)
+		return
 	}
 
 	if strings.HasPrefix(customId, "f") {
@@ -739,12 +1097,41 @@ func ComponentHandler(event *events.ComponentInteractionCreate) {
 				return
 			}
 
-			if slices.Contains(World.Tournaments[tUuid].Participants, pl.GetUUID()) {
-				event.CreateMessage(MessageContent("Jesteś już zapisany", true))
-				return
+			if World.Tournaments[tUuid].IsTeamMode() {
+				if err := World.JoinTournament(tUuid, pl); err != nil {
+					msgContent := ""
+
+					switch err.Error() {
+					case "NOT_IN_PARTY":
+						msgContent = "Musisz być w party, aby zapisać drużynę"
+					case "NOT_LEADER":
+						msgContent = "Tylko lider party może zapisać drużynę"
+					case "TEAM_TOO_SMALL":
+						msgContent = fmt.Sprintf("Drużyna musi mieć co najmniej %v graczy", World.Tournaments[tUuid].TeamSize)
+					case "ALREADY_REGISTERED":
+						msgContent = "Ktoś z party jest już zapisany"
+					case "TOURNAMENT_FULL", "tournament is full":
+						msgContent = "Brak wolnych miejsc"
+					case "TOURNAMENT_RUNNING", "tournament running":
+						msgContent = "Turniej już trwa"
+					default:
+						msgContent = "Nieznany błąd (turniej)"
+					}
+
+					event.CreateMessage(MessageContent(msgContent, true))
+					return
+				}
+			} else {
+				if slices.Contains(World.Tournaments[tUuid].Participants, pl.GetUUID()) {
+					event.CreateMessage(MessageContent("Jesteś już zapisany", true))
+					return
+				}
+
+				World.Tournaments[tUuid].Participants = append(World.Tournaments[tUuid].Participants, pl.GetUUID())
 			}
 
-			World.Tournaments[tUuid].Participants = append(World.Tournaments[tUuid].Participants, pl.GetUUID())
+			//Joining during check in counts as checking in
+			World.Tournaments[tUuid].CheckIn(pl.GetUUID())
 
 			var playerText string = ""
 
@@ -757,11 +1144,149 @@ func ComponentHandler(event *events.ComponentInteractionCreate) {
 			event.UpdateMessage(discord.NewMessageUpdateBuilder().
 				SetEmbeds(discord.NewEmbedBuilder().
 					SetTitle("Nowy turniej!").
-					SetDescriptionf("Zapisy na turniej `%v` otwarte!", World.Tournaments[tUuid].Name).
+					SetDescription(world.TournamentDescription(World.Tournaments[tUuid])).
 					SetFooterText("Ilość miejsc: " + playerText).
 					Build()).
 				Build(),
 			)
+		case "checkin":
+			tUuid := uuid.MustParse(segments[2])
+
+			pl := World.GetPlayer(event.User().ID.String())
+
+			if pl == nil {
+				event.CreateMessage(noCharMessage)
+				return
+			}
+
+			if err := World.CheckInTournament(tUuid, pl.GetUUID()); err != nil {
+				msgContent := ""
+
+				switch err.Error() {
+				case "TOURNAMENT_NOT_FOUND":
+					msgContent = "Turniej już się nie odbędzie"
+				case "CHECK_IN_CLOSED":
+					msgContent = "Potwierdzanie udziału jest zamknięte"
+				case "NOT_PARTICIPANT":
+					msgContent = "Nie jesteś zapisany na ten turniej"
+				case "ALREADY_CHECKED_IN":
+					msgContent = "Udział został już potwierdzony"
+				default:
+					msgContent = "Nieznany błąd (turniej)"
+				}
+
+				event.CreateMessage(MessageContent(msgContent, true))
+				return
+			}
+
+			event.CreateMessage(MessageContent("Potwierdzono udział w turnieju", true))
 		}
 	}
 }
+
+func HandlePartyInvite(event *events.ComponentInteractionCreate) {
+	customId := event.ComponentInteraction.Data.CustomID()
+	inviteUuid := uuid.MustParse(strings.Split(customId, "|")[1])
+
+	pl := World.GetPlayer(event.User().ID.String())
+
+	if pl == nil {
+		event.CreateMessage(noCharMessage)
+		return
+	}
+
+	var err error
+	resultText := ""
+
+	if strings.HasPrefix(customId, "party/res") {
+		err = World.AcceptPartyInvite(inviteUuid, pl)
+		resultText = "Dołączono do party"
+	} else {
+		err = World.RejectPartyInvite(inviteUuid, pl)
+		resultText = "Odrzucono zaproszenie"
+	}
+
+	if err != nil {
+		msgContent := ""
+
+		switch err.Error() {
+		case "INVITE_NOT_FOUND":
+			msgContent = "Zaproszenie nie jest już aktualne"
+		case "INVITE_EXPIRED":
+			msgContent = "Zaproszenie wygasło"
+		case "NOT_INVITED":
+			msgContent = "To zaproszenie nie jest do ciebie"
+		case "ALREADY_IN_PARTY":
+			event.CreateMessage(alreadyInParty)
+			return
+		case "PARTY_NOT_FOUND":
+			msgContent = "Party już nie istnieje"
+		case "PARTY_FULL":
+			msgContent = "Party jest pełne"
+		default:
+			msgContent = "Nieznany błąd (party)"
+		}
+
+		event.CreateMessage(MessageContent(msgContent, true))
+		return
+	}
+
+	event.UpdateMessage(discord.
+		NewMessageUpdateBuilder().
+		ClearContainerComponents().
+		SetContent(resultText).
+		Build(),
+	)
+}
+
+func HandleGuildInvite(event *events.ComponentInteractionCreate) {
+	customId := event.ComponentInteraction.Data.CustomID()
+	guildUuid := uuid.MustParse(strings.Split(customId, "|")[1])
+
+	pl := World.GetPlayer(event.User().ID.String())
+
+	if pl == nil {
+		event.CreateMessage(noCharMessage)
+		return
+	}
+
+	var err error
+	resultText := ""
+
+	if strings.HasPrefix(customId, "guild/res") {
+		err = World.AcceptGuildInvite(guildUuid, pl)
+		resultText = "Dołączono do gildii"
+	} else {
+		err = World.RejectGuildInvite(guildUuid, pl)
+		resultText = "Odrzucono zaproszenie"
+	}
+
+	if err != nil {
+		msgContent := ""
+
+		switch err.Error() {
+		case "GUILD_NOT_FOUND":
+			msgContent = "Gildia już nie istnieje"
+		case "NOT_INVITED":
+			msgContent = "Zaproszenie nie jest już aktualne"
+		case "INVITE_EXPIRED":
+			msgContent = "Zaproszenie wygasło"
+		case "ALREADY_IN_GUILD":
+			msgContent = "Jesteś już w gildii"
+		case "GUILD_FULL":
+			msgContent = "Gildia jest pełna"
+		default:
+			msgContent = "Nieznany błąd (gildia)"
+		}
+
+		event.CreateMessage(MessageContent(msgContent, true))
+		return
+	}
+
+	event.UpdateMessage(discord.
+		NewMessageUpdateBuilder().
+		ClearContainerComponents().
+		SetContent(resultText).
+		Build(),
+	)
+}
@@ -104,6 +104,10 @@ func (m *MessageBufferManager) Flush(channelID string) {
 			combinedContent.Components = append(combinedContent.Components, msg.Components...)
 		}
 
+		if len(msg.Files) > 0 {
+			combinedContent.Files = append(combinedContent.Files, msg.Files...)
+		}
+
 		if msg.Content != "" && combinedContent.Content == "" {
 			combinedContent.Content = msg.Content
 		} else if msg.Content != "" && combinedContent.Content != "" {
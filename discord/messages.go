@@ -51,3 +51,9 @@ var unknownError = discord.
 	Build()
 
 var messageUpdateClearComponents = discord.NewMessageUpdateBuilder().ClearContainerComponents().Build()
+
+var travelingMessage = discord.
+	NewMessageCreateBuilder().
+	SetContent("Twoja postać jest w podróży").
+	SetEphemeral(true).
+	Build()
@@ -1,6 +1,18 @@
 package discord
 
-import "github.com/disgoorg/disgo/discord"
+import (
+	"fmt"
+	"sao/data"
+	"sao/player"
+	"sao/types"
+	"sao/world/guild"
+	"sao/world/party"
+	"sao/world/tournament"
+	"strings"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/google/uuid"
+)
 
 var DISCORD_COMMANDS = []discord.ApplicationCommandCreate{
 	discord.SlashCommandCreate{
@@ -65,6 +77,10 @@ var DISCORD_COMMANDS = []discord.ApplicationCommandCreate{
 					},
 				},
 			},
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "reset",
+				Description: "Zresetuj umiejętności za złoto",
+			},
 		},
 	},
 	discord.SlashCommandCreate{
@@ -125,27 +141,10 @@ var DISCORD_COMMANDS = []discord.ApplicationCommandCreate{
 						Required:    true,
 					},
 					discord.ApplicationCommandOptionString{
-						Name:        "rola",
-						Description: "Rola",
-						Required:    true,
-						Choices: []discord.ApplicationCommandOptionChoiceString{
-							{
-								Name:  "Lider",
-								Value: "Lider",
-							},
-							{
-								Name:  "DPS",
-								Value: "DPS",
-							},
-							{
-								Name:  "Support",
-								Value: "Support",
-							},
-							{
-								Name:  "Tank",
-								Value: "Tank",
-							},
-						},
+						Name:         "rola",
+						Description:  "Rola",
+						Required:     true,
+						Autocomplete: true,
 					},
 				},
 			},
@@ -153,6 +152,23 @@ var DISCORD_COMMANDS = []discord.ApplicationCommandCreate{
 				Name:        "rozwiąż",
 				Description: "Rozwiąż party",
 			},
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "łupy",
+				Description: "Zmień sposób podziału łupów",
+				Options: []discord.ApplicationCommandOption{
+					discord.ApplicationCommandOptionInt{
+						Name:        "tryb",
+						Description: "Tryb podziału",
+						Required:    true,
+						Choices: []discord.ApplicationCommandOptionChoiceInt{
+							{Name: party.LootModeToString[party.LootEven], Value: int(party.LootEven)},
+							{Name: party.LootModeToString[party.LootDamage], Value: int(party.LootDamage)},
+							{Name: party.LootModeToString[party.LootRoundRobin], Value: int(party.LootRoundRobin)},
+							{Name: party.LootModeToString[party.LootNeedGreed], Value: int(party.LootNeedGreed)},
+						},
+					},
+				},
+			},
 		},
 	},
 	discord.SlashCommandCreate{
@@ -165,6 +181,233 @@ var DISCORD_COMMANDS = []discord.ApplicationCommandCreate{
 			},
 		},
 	},
+	discord.SlashCommandCreate{
+		Name:        "pojedynek",
+		Description: "Wyzwij gracza na pojedynek",
+		Options: []discord.ApplicationCommandOption{
+			discord.ApplicationCommandOptionUser{
+				Name:        "gracz",
+				Description: "Przeciwnik",
+				Required:    true,
+			},
+			discord.ApplicationCommandOptionInt{
+				Name:        "stawka",
+				Description: "Złoto stawiane przez każdą ze stron",
+			},
+		},
+	},
+	discord.SlashCommandCreate{
+		Name:        "podróż",
+		Description: "Podróżuj do innej lokacji",
+		Options: []discord.ApplicationCommandOption{
+			discord.ApplicationCommandOptionString{
+				Name:         "cel",
+				Description:  "Lokacja docelowa",
+				Required:     true,
+				Autocomplete: true,
+			},
+			discord.ApplicationCommandOptionBool{
+				Name:        "teleport",
+				Description: "Natychmiastowa podróż między punktami teleportacji za złoto",
+			},
+		},
+	},
+	discord.SlashCommandCreate{
+		Name:        "questy",
+		Description: "Zadania",
+		Options: []discord.ApplicationCommandOption{
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "lista",
+				Description: "Pokaż aktywne zadania",
+			},
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "dostępne",
+				Description: "Pokaż zadania dostępne w tej lokacji",
+			},
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "oddaj",
+				Description: "Oddaj ukończone zadanie",
+				Options: []discord.ApplicationCommandOption{
+					discord.ApplicationCommandOptionString{
+						Name:         "zadanie",
+						Description:  "Nazwa zadania",
+						Required:     true,
+						Autocomplete: true,
+					},
+				},
+			},
+		},
+	},
+	discord.SlashCommandCreate{
+		Name:        "craft",
+		Description: "Wytwarzanie przedmiotów",
+		Options: []discord.ApplicationCommandOption{
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "lista",
+				Description: "Pokaż dostępne przepisy",
+			},
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "wytwórz",
+				Description: "Wytwórz przedmiot",
+				Options: []discord.ApplicationCommandOption{
+					discord.ApplicationCommandOptionString{
+						Name:         "przepis",
+						Description:  "Nazwa przepisu",
+						Required:     true,
+						Autocomplete: true,
+					},
+				},
+			},
+		},
+	},
+	discord.SlashCommandCreate{
+		Name:        "furia",
+		Description: "Twoja furia",
+		Options: []discord.ApplicationCommandOption{
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "pokaż",
+				Description: "Pokaż furię",
+			},
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "awansuj",
+				Description: "Przenieś furię na kolejny poziom zaawansowania",
+			},
+		},
+	},
+	discord.SlashCommandCreate{
+		Name:        "gildia",
+		Description: "Zarządzaj gildią",
+		Options: []discord.ApplicationCommandOption{
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "załóż",
+				Description: "Załóż gildię",
+				Options: []discord.ApplicationCommandOption{
+					discord.ApplicationCommandOptionString{
+						Name:        "nazwa",
+						Description: "Nazwa gildii",
+						Required:    true,
+					},
+				},
+			},
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "pokaż",
+				Description: "Pokaż gildię",
+			},
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "zaproś",
+				Description: "Zaproś gracza do gildii",
+				Options: []discord.ApplicationCommandOption{
+					discord.ApplicationCommandOptionUser{
+						Name:        "gracz",
+						Description: "Gracz",
+						Required:    true,
+					},
+				},
+			},
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "awansuj",
+				Description: "Podnieś rangę członka",
+				Options: []discord.ApplicationCommandOption{
+					discord.ApplicationCommandOptionUser{
+						Name:        "gracz",
+						Description: "Gracz",
+						Required:    true,
+					},
+				},
+			},
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "degraduj",
+				Description: "Obniż rangę członka",
+				Options: []discord.ApplicationCommandOption{
+					discord.ApplicationCommandOptionUser{
+						Name:        "gracz",
+						Description: "Gracz",
+						Required:    true,
+					},
+				},
+			},
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "wyrzuć",
+				Description: "Wyrzuć członka z gildii",
+				Options: []discord.ApplicationCommandOption{
+					discord.ApplicationCommandOptionUser{
+						Name:        "gracz",
+						Description: "Gracz",
+						Required:    true,
+					},
+				},
+			},
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "opuść",
+				Description: "Opuść gildię",
+			},
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "wpłać",
+				Description: "Wpłać złoto lub przedmioty do banku gildii",
+				Options: []discord.ApplicationCommandOption{
+					discord.ApplicationCommandOptionInt{
+						Name:        "złoto",
+						Description: "Ilość złota",
+					},
+					discord.ApplicationCommandOptionString{
+						Name:         "przedmiot",
+						Description:  "Przedmiot z plecaka",
+						Autocomplete: true,
+					},
+					discord.ApplicationCommandOptionInt{
+						Name:        "ilość",
+						Description: "Ilość przedmiotów",
+					},
+				},
+			},
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "wypłać",
+				Description: "Wypłać złoto lub przedmioty z banku gildii",
+				Options: []discord.ApplicationCommandOption{
+					discord.ApplicationCommandOptionInt{
+						Name:        "złoto",
+						Description: "Ilość złota",
+					},
+					discord.ApplicationCommandOptionString{
+						Name:         "przedmiot",
+						Description:  "Przedmiot z banku",
+						Autocomplete: true,
+					},
+					discord.ApplicationCommandOptionInt{
+						Name:        "ilość",
+						Description: "Ilość przedmiotów",
+					},
+				},
+			},
+		},
+	},
+	discord.SlashCommandCreate{
+		Name:        "cmentarz",
+		Description: "Polegli bohaterowie",
+		Options: []discord.ApplicationCommandOption{
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "lista",
+				Description: "Pokaż poległe postacie",
+				Options: []discord.ApplicationCommandOption{
+					discord.ApplicationCommandOptionInt{
+						Name:        "strona",
+						Description: "Numer strony",
+					},
+				},
+			},
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "przywróć",
+				Description: "Przywróć poległą postać",
+				Options: []discord.ApplicationCommandOption{
+					discord.ApplicationCommandOptionInt{
+						Name:        "numer",
+						Description: "Numer postaci z listy",
+						Required:    true,
+					},
+				},
+			},
+		},
+	},
 	discord.SlashCommandCreate{
 		Name:        "turniej",
 		Description: "Zarządzaj turniejami",
@@ -182,6 +425,74 @@ var DISCORD_COMMANDS = []discord.ApplicationCommandCreate{
 						Name:        "max",
 						Description: "Maksymalna ilość graczy",
 					},
+					discord.ApplicationCommandOptionInt{
+						Name:        "format",
+						Description: "Format rozgrywek",
+						Choices: []discord.ApplicationCommandOptionChoiceInt{
+							{Name: tournament.FormatToString[tournament.SingleElimination], Value: int(tournament.SingleElimination)},
+							{Name: tournament.FormatToString[tournament.DoubleElimination], Value: int(tournament.DoubleElimination)},
+							{Name: tournament.FormatToString[tournament.RoundRobin], Value: int(tournament.RoundRobin)},
+							{Name: tournament.FormatToString[tournament.Swiss], Value: int(tournament.Swiss)},
+						},
+					},
+					discord.ApplicationCommandOptionInt{
+						Name:        "drużyny",
+						Description: "Ilość graczy w drużynie, zapisują się całe party",
+					},
+					discord.ApplicationCommandOptionInt{
+						Name:        "za",
+						Description: "Za ile minut turniej wystartuje sam, wcześniej trzeba potwierdzić udział",
+					},
+					discord.ApplicationCommandOptionString{
+						Name:         "nagrody",
+						Description:  "Pula nagród, domyślnie pierwsza z konfiguracji",
+						Autocomplete: true,
+					},
+				},
+			},
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "drabinka",
+				Description: "Pokaż drabinkę trwającego turnieju",
+				Options: []discord.ApplicationCommandOption{
+					discord.ApplicationCommandOptionString{
+						Name:         "nazwa",
+						Description:  "Nazwa turnieju",
+						Required:     true,
+						Autocomplete: true,
+					},
+				},
+			},
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "zakład",
+				Description: "Postaw złoto na mecz trwającego turnieju",
+				Options: []discord.ApplicationCommandOption{
+					discord.ApplicationCommandOptionString{
+						Name:         "nazwa",
+						Description:  "Nazwa turnieju",
+						Required:     true,
+						Autocomplete: true,
+					},
+					discord.ApplicationCommandOptionString{
+						Name:         "typ",
+						Description:  "Mecz i uczestnik, na którego stawiasz",
+						Required:     true,
+						Autocomplete: true,
+					},
+					discord.ApplicationCommandOptionInt{
+						Name:        "kwota",
+						Description: "Ilość złota",
+						Required:    true,
+					},
+				},
+			},
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "historia",
+				Description: "Rozegrane turnieje i wyniki gracza",
+				Options: []discord.ApplicationCommandOption{
+					discord.ApplicationCommandOptionUser{
+						Name:        "gracz",
+						Description: "Gracz, którego wyniki pokazać",
+					},
 				},
 			},
 			discord.ApplicationCommandOptionSubCommand{
@@ -212,7 +523,267 @@ func MessageEmbed(embeds ...discord.Embed) discord.MessageCreate {
 	return discord.NewMessageCreateBuilder().SetEmbeds(embeds...).Build()
 }
 
+func RecipeSummary(recipe types.Recipe, pl *player.Player) string {
+	text := ""
+
+	if recipe.Description != "" {
+		text += recipe.Description + "\n"
+	}
+
+	for _, input := range recipe.Inputs {
+		text += fmt.Sprintf("- %s %d/%d\n", data.Items[input.Item].Name, pl.Inventory.GetItemCount(input.Item), input.Count)
+	}
+
+	if recipe.Gold > 0 {
+		text += fmt.Sprintf("- Złoto: %d\n", recipe.Gold)
+	}
+
+	if recipe.Location != "" {
+		text += fmt.Sprintf("Wymagane miejsce: %s\n", recipe.Location)
+	}
+
+	return text + fmt.Sprintf("Wynik: %dx %s", recipe.Output.Count, data.Items[recipe.Output.Item].Name)
+}
+
+func ItemAffixSummary(item *types.PlayerItem) string {
+	if item.Stacks {
+		return ""
+	}
+
+	text := fmt.Sprintf("Rzadkość: %s", types.RarityToString[item.Rarity])
+
+	for _, affix := range item.Affixes {
+		if affix.Derived != nil {
+			text += fmt.Sprintf(
+				"\n- %d%% %s jako %s",
+				affix.Derived.Percent, types.StatToString[affix.Derived.Base], types.StatToString[affix.Derived.Derived],
+			)
+		} else {
+			text += fmt.Sprintf("\n- +%d %s", affix.Value, types.StatToString[affix.Stat])
+		}
+	}
+
+	return text
+}
+
+func QuestSummary(quest types.Quest, pl *player.Player) string {
+	text := ""
+
+	if quest.Description != "" {
+		text += quest.Description + "\n"
+	}
+
+	progress := pl.GetQuestProgress(quest)
+
+	for idx, objective := range quest.Objectives {
+		switch objective.Type {
+		case types.QUEST_KILL:
+			text += fmt.Sprintf("- Pokonaj: %s", objective.Target)
+
+			if objective.Location != "" {
+				text += fmt.Sprintf(" (%s)", objective.Location)
+			}
+		case types.QUEST_DELIVER:
+			text += fmt.Sprintf("- Dostarcz: %s", data.Items[uuid.MustParse(objective.Target)].Name)
+		case types.QUEST_LEVEL:
+			text += "- Osiągnij poziom"
+		case types.QUEST_TOURNAMENT_WIN:
+			text += "- Wygraj walkę w turnieju"
+		}
+
+		text += fmt.Sprintf(" %d/%d\n", progress[idx], objective.Count)
+	}
+
+	rewards := make([]string, 0)
+
+	for _, reward := range quest.Rewards {
+		switch reward.Type {
+		case types.LOOT_EXP:
+			rewards = append(rewards, fmt.Sprintf("%d XP", reward.Count))
+		case types.LOOT_GOLD:
+			rewards = append(rewards, fmt.Sprintf("%d złota", reward.Count))
+		case types.LOOT_ITEM:
+			rewards = append(rewards, fmt.Sprintf("%dx %s", reward.Count, data.Items[uuid.MustParse(reward.Item)].Name))
+		}
+	}
+
+	if len(rewards) > 0 {
+		text += "Nagroda: " + strings.Join(rewards, ", ") + "\n"
+	}
+
+	return text + fmt.Sprintf("Zleceniodawca: %s (%s)", quest.Giver, quest.Location)
+}
+
+func DuelErrorMessage(err error) string {
+	switch err.Error() {
+	case "SELF_DUEL":
+		return "Nie możesz wyzwać samego siebie"
+	case "INVALID_STAKE":
+		return "Nieprawidłowa stawka"
+	case "PLAYER_IN_FIGHT":
+		return "Jeden z graczy jest w walce"
+	case "WRONG_LOCATION":
+		return "Obaj gracze muszą być w tej samej lokacji"
+	case "PLAYER_DEAD":
+		return "Jeden z graczy nie żyje"
+	case "NOT_ENOUGH_GOLD":
+		return "Jeden z graczy nie ma wystarczająco złota"
+	case "DUEL_NOT_FOUND":
+		return "Pojedynek nie istnieje"
+	case "DUEL_EXPIRED":
+		return "Wyzwanie wygasło"
+	}
+
+	return "Nieznany błąd (pojedynek)"
+}
+
 type LevelField struct {
 	Level int
 	Field discord.EmbedField
 }
+
+func FuryRequirementsText(pl *player.Player, tier *types.FuryTier) string {
+	text := ""
+
+	for _, requirement := range tier.Requirements {
+		status := "❌"
+
+		if pl.IsFuryRequirementMet(requirement) {
+			status = "✅"
+		}
+
+		switch requirement.Type {
+		case types.FURY_REQ_LEVEL:
+			text += fmt.Sprintf("%s Poziom furii %d\n", status, requirement.Count)
+		case types.FURY_REQ_ITEM:
+			text += fmt.Sprintf("%s %dx %s\n", status, requirement.Count, data.Items[uuid.MustParse(requirement.Target)].Name)
+		case types.FURY_REQ_KILL:
+			text += fmt.Sprintf("%s Pokonaj: %s %d/%d\n", status, requirement.Target, pl.Fury.Kills[requirement.Target], requirement.Count)
+		}
+	}
+
+	if text == "" {
+		return "Brak"
+	}
+
+	return strings.TrimSuffix(text, "\n")
+}
+
+func GuildEmbed(guildObj *guild.Guild) discord.Embed {
+	membersText := ""
+
+	for rank := guild.RankLeader; rank >= guild.RankRecruit; rank-- {
+		for _, member := range guildObj.Members {
+			if member.Rank != rank {
+				continue
+			}
+
+			if memberObj, exists := World.Players[member.PlayerUuid]; exists {
+				membersText += fmt.Sprintf("%s: <@%s> - %s\n", guild.RankToString[rank], memberObj.Meta.UserID, memberObj.GetName())
+			}
+		}
+	}
+
+	lvlText := fmt.Sprintf("%d (%d/%d)", guildObj.GetLevel(), guildObj.XP, guildObj.NextLevelXP())
+
+	if guildObj.NextLevelXP() == -1 {
+		lvlText = fmt.Sprintf("%d MAX", guildObj.GetLevel())
+	}
+
+	bonusText := ""
+
+	for _, bonus := range guildObj.GetBonuses() {
+		if bonus.Percent {
+			bonusText += fmt.Sprintf("%s +%d%%\n", types.StatToString[bonus.Stat], bonus.Value)
+		} else {
+			bonusText += fmt.Sprintf("%s +%d\n", types.StatToString[bonus.Stat], bonus.Value)
+		}
+	}
+
+	if bonusText == "" {
+		bonusText = "Brak"
+	}
+
+	bankText := fmt.Sprintf("%d złota\n", guildObj.Bank.Gold)
+
+	for itemUuid, count := range guildObj.Bank.Items {
+		if item, exists := data.Items[itemUuid]; exists {
+			bankText += fmt.Sprintf("%dx %s\n", count, item.Name)
+		}
+	}
+
+	return discord.NewEmbedBuilder().
+		SetTitle(guildObj.Name).
+		AddField("Poziom", lvlText, true).
+		AddField("Członkowie", fmt.Sprintf("%d/%d", len(guildObj.Members), guildObj.MaxMembers()), true).
+		AddField("Skład", strings.TrimSuffix(membersText, "\n"), false).
+		AddField("Bonusy", strings.TrimSuffix(bonusText, "\n"), false).
+		AddField("Bank", strings.TrimSuffix(bankText, "\n"), false).
+		Build()
+}
+
+// Shows last tournaments and hall of fame, with player's record when pl isn't nil
+func TournamentHistoryEmbed(pl *player.Player) discord.Embed {
+	historyText := ""
+
+	for idx := len(World.TournamentHistory) - 1; idx >= 0 && idx >= len(World.TournamentHistory)-10; idx-- {
+		entry := World.TournamentHistory[idx]
+
+		winnerText := "brak"
+
+		if winner := entry.Tournament.GetWinner(); winner != nil {
+			winnerText = entry.GetName(*winner)
+		}
+
+		historyText += fmt.Sprintf(
+			"`%s` (<t:%d:d>, %s) - zwycięzca: %s\n",
+			entry.Tournament.Name, entry.FinishedAt.Unix(), tournament.FormatToString[entry.Tournament.Format], winnerText,
+		)
+	}
+
+	if historyText == "" {
+		historyText = "Nie rozegrano jeszcze żadnego turnieju"
+	}
+
+	fameText := ""
+
+	for idx, fameEntry := range tournament.GetHallOfFame(World.TournamentHistory, 5) {
+		fameText += fmt.Sprintf("%d. %s - %d zwycięstw\n", idx+1, fameEntry.Name, fameEntry.Wins)
+	}
+
+	if fameText == "" {
+		fameText = "Pusto"
+	}
+
+	embed := discord.NewEmbedBuilder().
+		SetTitle("Historia turniejów").
+		SetDescription(strings.TrimSuffix(historyText, "\n")).
+		AddField("Galeria sław", strings.TrimSuffix(fameText, "\n"), false)
+
+	if pl != nil {
+		record := tournament.GetPlayerRecord(World.TournamentHistory, pl.GetUUID())
+
+		bestText := "brak"
+
+		if record.BestPlace != 0 {
+			bestText = fmt.Sprintf("%d", record.BestPlace)
+		}
+
+		titlesText := "brak"
+
+		if len(pl.PvP.Titles) > 0 {
+			titlesText = strings.Join(pl.PvP.Titles, ", ")
+		}
+
+		embed.AddField(
+			"Wyniki: "+pl.GetName(),
+			fmt.Sprintf(
+				"Turnieje: %d, wygrane: %d, podia: %d\nMecze: %d W / %d P\nNajlepsze miejsce: %s\nTytuły: %s",
+				record.Played, record.Won, record.Podiums, record.MatchWins, record.MatchLosses, bestText, titlesText,
+			),
+			false,
+		)
+	}
+
+	return embed.Build()
+}
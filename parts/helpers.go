@@ -50,6 +50,21 @@ func AddConsts(vm *parts.VM) {
 
 		"LOOT_EXP":  int(types.LOOT_EXP),
 		"LOOT_GOLD": int(types.LOOT_GOLD),
+		"LOOT_ITEM": int(types.LOOT_ITEM),
+
+		"QUEST_KILL":           int(types.QUEST_KILL),
+		"QUEST_DELIVER":        int(types.QUEST_DELIVER),
+		"QUEST_LEVEL":          int(types.QUEST_LEVEL),
+		"QUEST_TOURNAMENT_WIN": int(types.QUEST_TOURNAMENT_WIN),
+
+		"FURY_REQ_LEVEL": int(types.FURY_REQ_LEVEL),
+		"FURY_REQ_ITEM":  int(types.FURY_REQ_ITEM),
+		"FURY_REQ_KILL":  int(types.FURY_REQ_KILL),
+
+		"PATH_CONTROL":   int(types.PathControl),
+		"PATH_ENDURANCE": int(types.PathEndurance),
+		"PATH_DAMAGE":    int(types.PathDamage),
+		"PATH_SPECIAL":   int(types.PathSpecial),
 
 		"ACTION_ATTACK":  int(types.ACTION_ATTACK),
 		"ACTION_DEFEND":  int(types.ACTION_DEFEND),
@@ -60,6 +75,7 @@ func AddConsts(vm *parts.VM) {
 		"ACTION_EFFECT":  int(types.ACTION_EFFECT),
 		"ACTION_DMG":     int(types.ACTION_DMG),
 		"ACTION_SUMMON":  int(types.ACTION_SUMMON),
+		"ACTION_SKIP":    int(types.ACTION_SKIP),
 
 		"SUMMON_FLAG_NONE":   int(types.SUMMON_FLAG_NONE),
 		"SUMMON_FLAG_ATTACK": int(types.SUMMON_FLAG_ATTACK),
@@ -183,6 +199,35 @@ func AddFunctions(vm *parts.VM) {
 		ent.ApplyEffect(actEffect)
 	})
 
+	env.DefineFunction("ApplyTimedEffect", func(ent types.PlayerEntity, effect any) {
+		dataMap := effect.(map[string]any)
+
+		actEffect := types.ActionEffect{
+			Effect:   types.Effect(dataMap["RTEffect"].(int)),
+			Value:    dataMap["RTValue"].(int),
+			Duration: dataMap["RTDuration"].(int),
+			Uuid:     uuid.New(),
+		}
+
+		if rawMeta, hasMeta := dataMap["RTMeta"]; hasMeta {
+			metaMap := rawMeta.(map[string]any)
+
+			isPercent, _ := metaMap["RTIsPercent"].(bool)
+
+			actEffect.Meta = types.ActionEffectStat{Stat: types.Stat(metaMap["RTStat"].(int)), IsPercent: isPercent}
+		}
+
+		ent.ApplyTimedEffect(actEffect)
+	})
+
+	env.DefineFunction("Heal", func(ent types.Entity, value int) {
+		ent.Heal(value)
+	})
+
+	env.DefineFunction("RestoreMana", func(ent types.Entity, value int) {
+		ent.RestoreMana(value)
+	})
+
 	vm.Enviroment.Append(&env)
 }
 
@@ -0,0 +1,58 @@
+package player
+
+import (
+	"sao/data"
+	"sao/types"
+
+	"github.com/google/uuid"
+)
+
+type DeathPenalty struct {
+	XP      int
+	Gold    int
+	Respawn types.EntityLocation
+}
+
+// Takes exp and gold, moves player to default location of the current floor and starts respawn timer
+func (p *Player) ApplyDeathPenalty() DeathPenalty {
+	xpLost := p.XP.Exp * data.WorldConfig.DeathXPLoss / 100
+	goldLost := p.Inventory.Gold * data.WorldConfig.DeathGoldLoss / 100
+
+	p.XP.Exp -= xpLost
+	p.Inventory.Gold -= goldLost
+
+	floor, exists := data.FloorMap[p.Meta.Location.Floor]
+
+	if !exists {
+		floor = data.FloorMap[data.WorldConfig.StartFloor]
+	}
+
+	p.Meta.Travel = nil
+	p.Meta.Location = types.EntityLocation{Floor: floor.Name, Location: floor.Default}
+
+	if data.WorldConfig.WeaknessValue > 0 && data.WorldConfig.WeaknessDuration > 0 {
+		for _, stat := range []types.Stat{types.STAT_AD, types.STAT_AP} {
+			p.Stats.TimedEffects = append(p.Stats.TimedEffects, types.ActionEffect{
+				Effect: types.EFFECT_STAT_DEC,
+				Value:  data.WorldConfig.WeaknessValue,
+				//Timed effects tick while waiting for respawn too
+				Duration: data.WorldConfig.RespawnTime + data.WorldConfig.WeaknessDuration,
+				Uuid:     uuid.New(),
+				Meta:     types.ActionEffectStat{Stat: stat, IsPercent: true},
+			})
+		}
+	}
+
+	p.Meta.Respawn = data.WorldConfig.RespawnTime
+
+	return DeathPenalty{XP: xpLost, Gold: goldLost, Respawn: p.Meta.Location}
+}
+
+// Returns true when player should be revived
+func (p *Player) TickRespawn() bool {
+	if p.Meta.Respawn > 0 {
+		p.Meta.Respawn--
+	}
+
+	return p.Meta.Respawn <= 0
+}
@@ -0,0 +1,102 @@
+package player
+
+import (
+	"errors"
+	"sao/types"
+	"sao/world/fury"
+
+	"github.com/google/uuid"
+)
+
+func (p *Player) BindFury(furyUuid uuid.UUID) error {
+	if p.Fury != nil {
+		return errors.New("FURY_ALREADY_BOUND")
+	}
+
+	newFury, err := fury.New(furyUuid, p.GetUUID())
+
+	if err != nil {
+		return err
+	}
+
+	p.Fury = newFury
+
+	return nil
+}
+
+func (p *Player) AddFuryXP(value int) {
+	if p.Fury != nil {
+		p.Fury.AddXP(value)
+	}
+}
+
+func (p *Player) IsFuryRequirementMet(requirement types.FuryRequirement) bool {
+	if p.Fury == nil {
+		return false
+	}
+
+	switch requirement.Type {
+	case types.FURY_REQ_LEVEL:
+		return p.Fury.XP.LVL >= requirement.Count
+	case types.FURY_REQ_ITEM:
+		return p.Inventory.GetItemCount(uuid.MustParse(requirement.Target)) >= requirement.Count
+	case types.FURY_REQ_KILL:
+		return p.Fury.Kills[requirement.Target] >= requirement.Count
+	}
+
+	return false
+}
+
+func (p *Player) TierUpFury() error {
+	if p.Fury == nil {
+		return errors.New("NO_FURY")
+	}
+
+	if p.Meta.FightInstance != nil {
+		return errors.New("PLAYER_IN_FIGHT")
+	}
+
+	nextTier := p.Fury.GetNextTier()
+
+	if nextTier == nil {
+		return errors.New("MAX_TIER")
+	}
+
+	items := make([]types.WithCount[uuid.UUID], 0)
+
+	for _, requirement := range nextTier.Requirements {
+		if !p.IsFuryRequirementMet(requirement) {
+			return errors.New("REQUIREMENTS_NOT_MET")
+		}
+
+		if requirement.Type == types.FURY_REQ_ITEM {
+			items = append(items, types.WithCount[uuid.UUID]{Item: uuid.MustParse(requirement.Target), Count: requirement.Count})
+		}
+	}
+
+	if err := p.Inventory.RemoveItems(items); err != nil {
+		return err
+	}
+
+	p.Fury.TierUp()
+
+	return nil
+}
+
+func SerializeFury(f *fury.Fury) map[string]any {
+	if f == nil {
+		return nil
+	}
+
+	return f.Serialize()
+}
+
+func DeserializeFury(rawData any) *fury.Fury {
+	furyData, ok := rawData.(map[string]any)
+
+	if !ok {
+		return nil
+	}
+
+	return fury.Deserialize(furyData)
+}
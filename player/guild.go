@@ -0,0 +1,22 @@
+package player
+
+import "sao/types"
+
+func (p *Player) GetGuildEffects() []types.ActionEffect {
+	effects := make([]types.ActionEffect, 0)
+
+	if p.Meta.Guild == nil {
+		return effects
+	}
+
+	for _, bonus := range p.Meta.Guild.Bonuses {
+		effects = append(effects, types.ActionEffect{
+			Effect:   types.EFFECT_STAT_INC,
+			Duration: -1,
+			Value:    bonus.Value,
+			Meta:     types.ActionEffectStat{Stat: bonus.Stat, IsPercent: bonus.Percent},
+		})
+	}
+
+	return effects
+}
@@ -34,7 +34,25 @@ func (inv *PlayerInventory) Serialize() map[string]any {
 	items := make([]map[string]any, 0)
 
 	for _, item := range inv.Items {
-		items = append(items, map[string]any{"uuid": item.UUID.String(), "count": item.Count})
+		affixes := make([]map[string]any, 0)
+
+		for _, affix := range item.Affixes {
+			if affix.Derived != nil {
+				affixes = append(affixes, map[string]any{
+					"base": affix.Derived.Base, "derived": affix.Derived.Derived, "percent": affix.Derived.Percent,
+				})
+			} else {
+				affixes = append(affixes, map[string]any{"stat": affix.Stat, "value": affix.Value})
+			}
+		}
+
+		items = append(items, map[string]any{
+			"uuid":     item.UUID.String(),
+			"count":    item.Count,
+			"instance": item.Instance.String(),
+			"rarity":   item.Rarity,
+			"affixes":  affixes,
+		})
 	}
 
 	lvlSkills := make(map[int]any)
@@ -59,12 +77,50 @@ func DeserializeInventory(rawData map[string]any) PlayerInventory {
 
 	inv.Gold = int(rawData["gold"].(float64))
 
-	if rawItemData, okay := rawData["items"].([]map[string]any); okay {
-		for _, item := range rawItemData {
-			uuid, _ := uuid.Parse(item["uuid"].(string))
-			copy := data.Items[uuid]
+	if rawItemData, okay := rawData["items"].([]any); okay {
+		for _, rawItem := range rawItemData {
+			item := rawItem.(map[string]any)
+
+			itemUuid, _ := uuid.Parse(item["uuid"].(string))
 
-			copy.Count = item["count"].(int)
+			copy, exists := data.Items[itemUuid]
+
+			if !exists {
+				continue
+			}
+
+			copy.Count = int(item["count"].(float64))
+
+			if rawInstance, hasInstance := item["instance"].(string); hasInstance {
+				copy.Instance, _ = uuid.Parse(rawInstance)
+			}
+
+			if copy.Instance == uuid.Nil {
+				copy.Instance = uuid.New()
+			}
+
+			if rarity, hasRarity := item["rarity"].(float64); hasRarity {
+				copy.Rarity = types.Rarity(rarity)
+			}
+
+			if rawAffixes, hasAffixes := item["affixes"].([]any); hasAffixes {
+				for _, rawAffix := range rawAffixes {
+					affix := rawAffix.(map[string]any)
+
+					if _, isDerived := affix["derived"]; isDerived {
+						copy.Affixes = append(copy.Affixes, types.ItemAffix{Derived: &types.DerivedStat{
+							Base:    types.Stat(affix["base"].(float64)),
+							Derived: types.Stat(affix["derived"].(float64)),
+							Percent: int(affix["percent"].(float64)),
+							Source:  copy.Instance,
+						}})
+					} else {
+						copy.Affixes = append(copy.Affixes, types.ItemAffix{
+							Stat: types.Stat(affix["stat"].(float64)), Value: int(affix["value"].(float64)),
+						})
+					}
+				}
+			}
 
 			inv.Items = append(inv.Items, &copy)
 		}
@@ -88,6 +144,10 @@ func DeserializeInventory(rawData map[string]any) PlayerInventory {
 }
 
 func (inv *PlayerInventory) AddItem(item *types.PlayerItem) {
+	if item.Instance == uuid.Nil {
+		item.Instance = uuid.New()
+	}
+
 	for _, invItem := range inv.Items {
 		if invItem.UUID == item.UUID && invItem.Stacks && invItem.Count < invItem.MaxCount {
 			if invItem.Count+item.Count > invItem.MaxCount {
@@ -108,11 +168,7 @@ func (inv PlayerInventory) GetStat(stat types.Stat) int {
 	value := 0
 
 	for _, item := range inv.Items {
-		val, exists := item.Stats[stat]
-
-		if exists {
-			value += val
-		}
+		value += item.GetStat(stat)
 	}
 
 	for key, skillInfo := range inv.LevelSkills {
@@ -132,7 +188,7 @@ func (inv PlayerInventory) GetDerivedStats() []types.DerivedStat {
 	statList := make([]types.DerivedStat, 0)
 
 	for _, item := range inv.Items {
-		statList = append(statList, item.DerivedStats...)
+		statList = append(statList, item.GetDerivedStats()...)
 	}
 
 	for key, skillInfo := range inv.LevelSkills {
@@ -158,8 +214,67 @@ func (inv *PlayerInventory) UseItem(itemUuid uuid.UUID, owner types.PlayerEntity
 			if item.Count == 0 && item.Consume {
 				inv.Items = slices.Delete(inv.Items, i, i+1)
 			}
+
+			return
+		}
+	}
+}
+
+func (inv *PlayerInventory) GetItem(itemUuid uuid.UUID) *types.PlayerItem {
+	for _, item := range inv.Items {
+		if item.UUID == itemUuid {
+			return item
+		}
+	}
+
+	return nil
+}
+
+func (inv *PlayerInventory) GetItemCount(itemUuid uuid.UUID) int {
+	count := 0
+
+	for _, item := range inv.Items {
+		if item.UUID == itemUuid {
+			count += item.Count
+		}
+	}
+
+	return count
+}
+
+// Removes all of the items or none of them
+func (inv *PlayerInventory) RemoveItems(items []types.WithCount[uuid.UUID]) error {
+	required := make(map[uuid.UUID]int)
+
+	for _, item := range items {
+		required[item.Item] += item.Count
+	}
+
+	for itemUuid, count := range required {
+		if inv.GetItemCount(itemUuid) < count {
+			return errors.New("NOT_ENOUGH_ITEMS")
+		}
+	}
+
+	for itemUuid, count := range required {
+		for i := len(inv.Items) - 1; i >= 0 && count > 0; i-- {
+			item := inv.Items[i]
+
+			if item.UUID != itemUuid {
+				continue
+			}
+
+			if item.Count > count {
+				item.Count -= count
+				count = 0
+			} else {
+				count -= item.Count
+				inv.Items = slices.Delete(inv.Items, i, i+1)
+			}
 		}
 	}
+
+	return nil
 }
 
 func (inv *PlayerInventory) UpgradeSkill(lvl int, upgradeIdx int) error {
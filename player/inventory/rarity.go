@@ -0,0 +1,93 @@
+package inventory
+
+import (
+	"sao/types"
+	"sao/utils"
+)
+
+type rarityChance struct {
+	Rarity types.Rarity
+	Chance int
+}
+
+// Out of 100, checked in order
+var rarityChances = []rarityChance{
+	{Rarity: types.RARITY_LEGENDARY, Chance: 1},
+	{Rarity: types.RARITY_EPIC, Chance: 4},
+	{Rarity: types.RARITY_RARE, Chance: 10},
+	{Rarity: types.RARITY_UNCOMMON, Chance: 25},
+}
+
+type statRange struct {
+	Stat types.Stat
+	Min  int
+	Max  int
+}
+
+var affixStats = []statRange{
+	{Stat: types.STAT_HP, Min: 20, Max: 60},
+	{Stat: types.STAT_AD, Min: 5, Max: 15},
+	{Stat: types.STAT_AP, Min: 5, Max: 15},
+	{Stat: types.STAT_DEF, Min: 3, Max: 10},
+	{Stat: types.STAT_MR, Min: 3, Max: 10},
+	{Stat: types.STAT_SPD, Min: 2, Max: 6},
+	{Stat: types.STAT_AGL, Min: 1, Max: 5},
+	{Stat: types.STAT_MANA, Min: 1, Max: 3},
+}
+
+var affixDerived = [][2]types.Stat{
+	{types.STAT_HP, types.STAT_AD},
+	{types.STAT_AD, types.STAT_AP},
+	{types.STAT_DEF, types.STAT_AD},
+	{types.STAT_MR, types.STAT_AP},
+}
+
+func RollRarity() types.Rarity {
+	roll := utils.RandomNumber(1, 100)
+
+	for _, entry := range rarityChances {
+		if roll <= entry.Chance {
+			return entry.Rarity
+		}
+
+		roll -= entry.Chance
+	}
+
+	return types.RARITY_COMMON
+}
+
+// Stacking items can't hold per instance data so they are always common
+func RollItem(item *types.PlayerItem) {
+	if item.Stacks {
+		return
+	}
+
+	item.Rarity = RollRarity()
+	item.Affixes = make([]types.ItemAffix, 0)
+
+	//Each rarity tier adds one affix and 25% to rolled values
+	scale := 100 + 25*int(item.Rarity)
+
+	for i := 0; i < int(item.Rarity); i++ {
+		//One in four affixes is a derived stat
+		if utils.RandomNumber(1, 4) == 1 {
+			pair := utils.RandomElement(affixDerived)
+
+			item.Affixes = append(item.Affixes, types.ItemAffix{Derived: &types.DerivedStat{
+				Base:    pair[0],
+				Derived: pair[1],
+				Percent: utils.PercentOf(utils.RandomNumber(2, 5), scale),
+				Source:  item.Instance,
+			}})
+
+			continue
+		}
+
+		statInfo := utils.RandomElement(affixStats)
+
+		item.Affixes = append(item.Affixes, types.ItemAffix{
+			Stat:  statInfo.Stat,
+			Value: utils.PercentOf(utils.RandomNumber(statInfo.Min, statInfo.Max), scale),
+		})
+	}
+}
@@ -3,12 +3,16 @@ package player
 import (
 	"errors"
 	"fmt"
+	"maps"
 	"sao/base"
 	"sao/data"
 	"sao/player/inventory"
 	"sao/types"
 	"sao/utils"
+	"sao/world/fury"
+	"sao/world/guild"
 	"sao/world/party"
+	"slices"
 	"strconv"
 
 	"github.com/google/uuid"
@@ -19,6 +23,8 @@ type PlayerStats struct {
 	Effects     []types.ActionEffect
 	Defending   bool
 	CurrentMana int
+	//Effects lasting outside of fights, duration in minutes
+	TimedEffects []types.ActionEffect
 }
 
 type PlayerXP struct {
@@ -30,6 +36,15 @@ type PartialParty struct {
 	Role         party.PartyRole
 	UUID         uuid.UUID
 	MembersCount int
+	//Roles taken by all members, used for synergies
+	Roles []party.PartyRole
+}
+
+type PartialGuild struct {
+	UUID uuid.UUID
+	Rank guild.GuildRank
+	//Bonuses of all levels reached by the guild
+	Bonuses []types.GuildBonus
 }
 
 type PlayerMeta struct {
@@ -37,8 +52,15 @@ type PlayerMeta struct {
 	UserID        string
 	FightInstance *uuid.UUID
 	Party         *PartialParty
+	Guild         *PartialGuild
 	Transaction   *uuid.UUID
 	WaitToHeal    bool
+	//Only used with per player floor unlocks
+	UnlockedFloors []string
+	Location       types.EntityLocation
+	Travel         *PlayerTravel
+	//Minutes until respawn after death
+	Respawn int
 }
 
 func (pM *PlayerMeta) Serialize() map[string]any {
@@ -48,10 +70,31 @@ func (pM *PlayerMeta) Serialize() map[string]any {
 		party = pM.Party.UUID.String()
 	}
 
+	guild := ""
+
+	if pM.Guild != nil {
+		guild = pM.Guild.UUID.String()
+	}
+
+	var travel map[string]any = nil
+
+	if pM.Travel != nil {
+		travel = map[string]any{
+			"floor":     pM.Travel.Destination.Floor,
+			"location":  pM.Travel.Destination.Location,
+			"remaining": pM.Travel.Remaining,
+		}
+	}
+
 	return map[string]any{
-		"uuid":  pM.OwnUUID.String(),
-		"uid":   pM.UserID,
-		"party": party,
+		"uuid":     pM.OwnUUID.String(),
+		"uid":      pM.UserID,
+		"party":    party,
+		"guild":    guild,
+		"floors":   pM.UnlockedFloors,
+		"location": []string{pM.Location.Floor, pM.Location.Location},
+		"travel":   travel,
+		"respawn":  pM.Respawn,
 	}
 }
 
@@ -63,17 +106,23 @@ type Player struct {
 	Inventory    inventory.PlayerInventory
 	LevelStats   map[types.Stat]int
 	DefaultStats map[types.Stat]int
+	Quests       QuestLog
+	PvP          PvPStats
+	Fury         *fury.Fury
 }
 
 func (p *Player) Serialize() map[string]any {
 	return map[string]any{
 		"name":          p.Name,
 		"xp":            []int{p.XP.Level, p.XP.Exp},
-		"stats":         map[string]any{"hp": p.Stats.HP, "current_mana": p.Stats.CurrentMana, "effects": p.Stats.Effects},
+		"stats":         map[string]any{"hp": p.Stats.HP, "current_mana": p.Stats.CurrentMana, "effects": p.Stats.Effects, "timed_effects": SerializeTimedEffects(p.Stats.TimedEffects)},
 		"level_stats":   p.LevelStats,
 		"default_stats": p.DefaultStats,
 		"meta":          p.Meta.Serialize(),
 		"inventory":     p.Inventory.Serialize(),
+		"quests":        p.Quests.Serialize(),
+		"pvp":           p.PvP.Serialize(),
+		"fury":          SerializeFury(p.Fury),
 	}
 }
 
@@ -109,14 +158,18 @@ func Deserialize(data map[string]any) *Player {
 			Exp:   int(data["xp"].([]any)[1].(float64)),
 		},
 		PlayerStats{
-			HP:          int(data["stats"].(map[string]any)["hp"].(float64)),
-			Effects:     DeserializeEffects(data["stats"].(map[string]any)["effects"].([]any)),
-			CurrentMana: int(data["stats"].(map[string]any)["current_mana"].(float64)),
+			HP:           int(data["stats"].(map[string]any)["hp"].(float64)),
+			Effects:      DeserializeEffects(data["stats"].(map[string]any)["effects"].([]any)),
+			CurrentMana:  int(data["stats"].(map[string]any)["current_mana"].(float64)),
+			TimedEffects: DeserializeTimedEffects(data["stats"].(map[string]any)["timed_effects"]),
 		},
 		*DeserializeMeta(data["meta"].(map[string]any)),
 		inventory.DeserializeInventory(data["inventory"].(map[string]any)),
 		DeserializeLevelStats(data["level_stats"].(map[string]any)),
 		DeserializeDefaultStats(data["default_stats"].(map[string]any)),
+		DeserializeQuestLog(data["quests"]),
+		DeserializePvPStats(data["pvp"]),
+		DeserializeFury(data["fury"]),
 	}
 }
 
@@ -140,6 +193,59 @@ func DeserializeEffects(data []any) []types.ActionEffect {
 	return temp
 }
 
+func SerializeTimedEffects(effects []types.ActionEffect) []map[string]any {
+	temp := make([]map[string]any, 0)
+
+	for _, effect := range effects {
+		effectData := map[string]any{
+			"effect":   effect.Effect,
+			"value":    effect.Value,
+			"duration": effect.Duration,
+			"uuid":     effect.Uuid.String(),
+		}
+
+		if statMeta, ok := effect.Meta.(types.ActionEffectStat); ok {
+			effectData["stat"] = statMeta.Stat
+			effectData["percent"] = statMeta.IsPercent
+		}
+
+		temp = append(temp, effectData)
+	}
+
+	return temp
+}
+
+func DeserializeTimedEffects(data any) []types.ActionEffect {
+	temp := make([]types.ActionEffect, 0)
+
+	rawList, ok := data.([]any)
+
+	if !ok {
+		return temp
+	}
+
+	for _, rawEffect := range rawList {
+		effect := rawEffect.(map[string]any)
+
+		actionEffect := types.ActionEffect{
+			Effect:   types.Effect(effect["effect"].(float64)),
+			Value:    int(effect["value"].(float64)),
+			Duration: int(effect["duration"].(float64)),
+			Uuid:     uuid.MustParse(effect["uuid"].(string)),
+		}
+
+		if stat, hasStat := effect["stat"]; hasStat {
+			actionEffect.Meta = types.ActionEffectStat{
+				Stat: types.Stat(stat.(float64)), IsPercent: effect["percent"].(bool),
+			}
+		}
+
+		temp = append(temp, actionEffect)
+	}
+
+	return temp
+}
+
 func DeserializeMeta(data map[string]any) *PlayerMeta {
 	var partyTemp *PartialParty = nil
 
@@ -150,10 +256,52 @@ func DeserializeMeta(data map[string]any) *PlayerMeta {
 		}
 	}
 
+	var guildTemp *PartialGuild = nil
+
+	if rawGuild, ok := data["guild"].(string); ok && rawGuild != "" {
+		guildTemp = &PartialGuild{UUID: uuid.MustParse(rawGuild), Bonuses: make([]types.GuildBonus, 0)}
+	}
+
+	unlockedFloors := make([]string, 0)
+
+	if rawFloors, ok := data["floors"].([]any); ok {
+		for _, floor := range rawFloors {
+			unlockedFloors = append(unlockedFloors, floor.(string))
+		}
+	}
+
+	location := GetStartLocation()
+
+	if rawLocation, ok := data["location"].([]any); ok && len(rawLocation) == 2 {
+		location = types.EntityLocation{Floor: rawLocation[0].(string), Location: rawLocation[1].(string)}
+	}
+
+	var travel *PlayerTravel = nil
+
+	if rawTravel, ok := data["travel"].(map[string]any); ok {
+		travel = &PlayerTravel{
+			Destination: types.EntityLocation{
+				Floor: rawTravel["floor"].(string), Location: rawTravel["location"].(string),
+			},
+			Remaining: int(rawTravel["remaining"].(float64)),
+		}
+	}
+
+	respawn := 0
+
+	if rawRespawn, ok := data["respawn"].(float64); ok {
+		respawn = int(rawRespawn)
+	}
+
 	return &PlayerMeta{
-		OwnUUID: uuid.MustParse(data["uuid"].(string)),
-		UserID:  data["uid"].(string),
-		Party:   partyTemp,
+		OwnUUID:        uuid.MustParse(data["uuid"].(string)),
+		UserID:         data["uid"].(string),
+		Party:          partyTemp,
+		Guild:          guildTemp,
+		UnlockedFloors: unlockedFloors,
+		Location:       location,
+		Travel:         travel,
+		Respawn:        respawn,
 	}
 }
 
@@ -210,17 +358,16 @@ func (p *Player) DamageShields(dmg int) int {
 func (p *Player) AddEXP(value int) {
 	p.XP.Exp += value
 
-	maxLevel := (data.FloorMap.GetUnlockedFloorCount() * 5) - 1
+	maxLevel := p.GetLevelCap()
 
+	//Levels above the cap are kept, only further level ups are blocked
 	if p.XP.Level >= maxLevel {
-		p.XP.Level = maxLevel
 		p.XP.Exp = 0
 		return
 	}
 
 	for p.XP.Exp >= ((p.XP.Level * 100) + 100) {
 		if p.XP.Level >= maxLevel {
-			p.XP.Level = maxLevel
 			p.XP.Exp = 0
 			return
 		}
@@ -246,6 +393,37 @@ func (p *Player) GetCurrentHP() int {
 	return p.Stats.HP
 }
 
+func (p *Player) IsFloorUnlocked(floor types.Floor) bool {
+	if floor.Unlocked {
+		return true
+	}
+
+	return data.WorldConfig.FloorUnlockPerPlayer && slices.Contains(p.Meta.UnlockedFloors, floor.Name)
+}
+
+// Returns true if floor wasn't unlocked before
+func (p *Player) UnlockFloor(name string) bool {
+	if slices.Contains(p.Meta.UnlockedFloors, name) {
+		return false
+	}
+
+	p.Meta.UnlockedFloors = append(p.Meta.UnlockedFloors, name)
+
+	return true
+}
+
+func (p *Player) GetLevelCap() int {
+	unlockedFloors := 0
+
+	for _, floor := range data.FloorMap {
+		if floor.CountsAsUnlocked && p.IsFloorUnlocked(floor) {
+			unlockedFloors++
+		}
+	}
+
+	return (unlockedFloors * 5) - 1
+}
+
 func (p *Player) AddGold(value int) {
 	p.Inventory.Gold += value
 }
@@ -303,62 +481,8 @@ func (p *Player) GetAllEffects() []types.ActionEffect {
 		})
 	}
 
-	if p.Meta.Party != nil {
-		switch p.Meta.Party.Role {
-		case party.DPS:
-			increase := 10 + (p.Meta.Party.MembersCount-1)*5
-
-			temporaryEffects = append(temporaryEffects, types.ActionEffect{
-				Effect:   types.EFFECT_STAT_INC,
-				Duration: -1,
-				Value:    increase,
-				Meta:     types.ActionEffectStat{Stat: types.STAT_AD, IsPercent: true},
-			}, types.ActionEffect{
-				Effect:   types.EFFECT_STAT_INC,
-				Duration: -1,
-				Value:    increase,
-				Meta:     types.ActionEffectStat{Stat: types.STAT_AP, IsPercent: true},
-			})
-		case party.Tank:
-			temporaryEffects = append(temporaryEffects, types.ActionEffect{
-				Effect:   types.EFFECT_STAT_INC,
-				Duration: -1,
-				Value:    25,
-				Meta:     types.ActionEffectStat{Stat: types.STAT_DEF},
-			}, types.ActionEffect{
-				Effect:   types.EFFECT_STAT_INC,
-				Duration: -1,
-				Value:    25,
-				Meta:     types.ActionEffectStat{Stat: types.STAT_MR},
-			}, types.ActionEffect{
-				Effect:   types.EFFECT_STAT_INC,
-				Duration: -1,
-				Value:    (p.Meta.Party.MembersCount - 1) * 5,
-				Meta:     types.ActionEffectStat{Stat: types.STAT_HP, IsPercent: true},
-			}, types.ActionEffect{
-				Effect:   types.EFFECT_STAT_INC,
-				Duration: -1,
-				Value:    (p.Meta.Party.MembersCount - 1) * 5,
-				Meta:     types.ActionEffectStat{Stat: types.STAT_DEF, IsPercent: true},
-			}, types.ActionEffect{
-				Effect:   types.EFFECT_STAT_INC,
-				Duration: -1,
-				Value:    (p.Meta.Party.MembersCount - 1) * 5,
-				Meta:     types.ActionEffectStat{Stat: types.STAT_MR, IsPercent: true},
-			})
-
-			if (p.Meta.Party.MembersCount - 1) > 2 {
-				temporaryEffects = append(temporaryEffects, types.ActionEffect{Effect: types.EFFECT_TAUNT, Duration: -1})
-			}
-		case party.Support:
-			temporaryEffects = append(temporaryEffects, types.ActionEffect{
-				Effect:   types.EFFECT_STAT_INC,
-				Duration: -1,
-				Value:    15 + (p.Meta.Party.MembersCount-1)*5,
-				Meta:     types.ActionEffectStat{Stat: types.STAT_HEAL_POWER, IsPercent: true},
-			})
-		}
-	}
+	temporaryEffects = append(temporaryEffects, p.GetPartyEffects()...)
+	temporaryEffects = append(temporaryEffects, p.GetGuildEffects()...)
 
 	return append(temporaryEffects, p.Stats.Effects...)
 }
@@ -422,6 +546,10 @@ func (p *Player) GetSkills() []types.PlayerSkill {
 		arr = append(arr, skill.Skill)
 	}
 
+	if p.Fury != nil {
+		arr = append(arr, p.Fury.GetSkills()...)
+	}
+
 	return arr
 }
 
@@ -456,7 +584,7 @@ func (p *Player) GetStat(stat types.Stat) int {
 	statValue := p.GetDefaultStat(stat)
 	percentValue := 0
 
-	for _, effect := range p.GetAllEffects() {
+	for _, effect := range append(p.GetAllEffects(), p.Stats.TimedEffects...) {
 		if effect.Effect == types.EFFECT_STAT_INC {
 
 			if value, ok := effect.Meta.(types.ActionEffectStat); ok {
@@ -494,6 +622,10 @@ func (p *Player) GetStat(stat types.Stat) int {
 
 	statValue += p.Inventory.GetStat(stat)
 
+	if p.Fury != nil {
+		statValue += p.Fury.GetStat(stat)
+	}
+
 	for _, effect := range p.GetDerivedStats() {
 		if effect.Derived == stat {
 			if effect.Base == effect.Derived {
@@ -665,6 +797,28 @@ func (p *Player) TriggerEvent(event types.SkillTrigger, data types.EventData, me
 		}
 	}
 
+	if p.Fury != nil {
+		for _, skill := range p.Fury.GetSkills() {
+			trigger := skill.GetTrigger()
+
+			if trigger.Type != types.TRIGGER_PASSIVE || trigger.Event != event {
+				continue
+			}
+
+			if cost := skill.GetCost(); cost != 0 {
+				if cost > p.GetCurrentMana() {
+					continue
+				} else {
+					p.Stats.CurrentMana -= cost
+				}
+			}
+
+			if temp := skill.Execute(p, data.Target, data.Fight, meta); temp != nil {
+				returnMeta = append(returnMeta, temp)
+			}
+		}
+	}
+
 	for _, effect := range p.Inventory.TempSkills {
 		trigger := effect.Value.GetTrigger()
 
@@ -781,6 +935,54 @@ func (p *Player) GetAvailableSkillActions() int {
 	return overall - used
 }
 
+func (p *Player) GetRespecCost() int {
+	return p.XP.Level * data.WorldConfig.RespecCost
+}
+
+// Returns amount of refunded skill actions
+func (p *Player) Respec(location *types.Location) (int, error) {
+	if p.Meta.FightInstance != nil {
+		return 0, errors.New("PLAYER_IN_FIGHT")
+	}
+
+	if location == nil || !location.CityPart {
+		return 0, errors.New("WRONG_LOCATION")
+	}
+
+	refunded := len(p.Inventory.LevelSkills)
+
+	for _, skill := range p.Inventory.LevelSkills {
+		for i := range skill.Skill.GetUpgrades() {
+			if inventory.HasUpgrade(skill.Upgrades, i) {
+				refunded++
+			}
+		}
+	}
+
+	if refunded == 0 && maps.Equal(p.LevelStats, data.PlayerDefaults.Level) {
+		return 0, errors.New("NOTHING_TO_RESET")
+	}
+
+	if p.Inventory.Gold < p.GetRespecCost() {
+		return 0, errors.New("NOT_ENOUGH_GOLD")
+	}
+
+	p.Inventory.Gold -= p.GetRespecCost()
+	p.Inventory.LevelSkills = make(map[int]*inventory.LevelSkillInfo)
+	//Skills only add on top of the default growth per level
+	p.LevelStats = maps.Clone(data.PlayerDefaults.Level)
+
+	if p.Stats.HP > p.GetStat(types.STAT_HP) {
+		p.Stats.HP = p.GetStat(types.STAT_HP)
+	}
+
+	if p.Stats.CurrentMana > p.GetStat(types.STAT_MANA) {
+		p.Stats.CurrentMana = p.GetStat(types.STAT_MANA)
+	}
+
+	return refunded, nil
+}
+
 func (p *Player) SetLevelSkillMeta(lvl int, meta any) {
 	p.Inventory.LevelSkills[lvl].Meta = meta
 }
@@ -793,6 +995,122 @@ func (p *Player) UseItem(item uuid.UUID, target types.Entity, fight types.FightI
 	p.Inventory.UseItem(item, p, target, fight)
 }
 
+func (p *Player) UseItemOutOfFight(itemUuid uuid.UUID) error {
+	if p.Meta.FightInstance != nil {
+		return errors.New("PLAYER_IN_FIGHT")
+	}
+
+	item := p.Inventory.GetItem(itemUuid)
+
+	if item == nil {
+		return errors.New("ITEM_NOT_FOUND")
+	}
+
+	if !item.OutOfFight || item.Hidden {
+		return errors.New("ITEM_NOT_USABLE")
+	}
+
+	if item.Consume && item.Count <= 0 {
+		return errors.New("ITEM_NOT_USABLE")
+	}
+
+	if item.Fury != "" {
+		if err := p.BindFury(uuid.MustParse(item.Fury)); err != nil {
+			return err
+		}
+	}
+
+	p.Inventory.UseItem(itemUuid, p, p, nil)
+
+	return nil
+}
+
+// Used for drops and crafting, non stacking items get a rolled rarity per instance
+func (p *Player) GiveItem(itemUuid uuid.UUID, count int) ([]*types.PlayerItem, error) {
+	template, exists := data.Items[itemUuid]
+
+	if !exists {
+		return nil, errors.New("ITEM_NOT_FOUND")
+	}
+
+	if template.Stacks {
+		template.Count = count
+		template.Instance = uuid.New()
+
+		p.Inventory.AddItem(&template)
+
+		return []*types.PlayerItem{&template}, nil
+	}
+
+	given := make([]*types.PlayerItem, 0)
+
+	for i := 0; i < count; i++ {
+		item := template
+
+		item.Count = 1
+		item.Instance = uuid.New()
+
+		inventory.RollItem(&item)
+
+		p.Inventory.AddItem(&item)
+
+		given = append(given, &item)
+	}
+
+	return given, nil
+}
+
+func (p *Player) Craft(recipe types.Recipe, location *types.Location) ([]*types.PlayerItem, error) {
+	if p.Meta.FightInstance != nil {
+		return nil, errors.New("PLAYER_IN_FIGHT")
+	}
+
+	if recipe.Location != "" && (location == nil || !slices.Contains(location.Flags, recipe.Location)) {
+		return nil, errors.New("WRONG_LOCATION")
+	}
+
+	if p.Inventory.Gold < recipe.Gold {
+		return nil, errors.New("NOT_ENOUGH_GOLD")
+	}
+
+	if err := p.Inventory.RemoveItems(recipe.Inputs); err != nil {
+		return nil, err
+	}
+
+	p.Inventory.Gold -= recipe.Gold
+
+	return p.GiveItem(recipe.Output.Item, recipe.Output.Count)
+}
+
+func (p *Player) ApplyTimedEffect(effect types.ActionEffect) {
+	if effect.Uuid == uuid.Nil {
+		effect.Uuid = uuid.New()
+	}
+
+	p.Stats.TimedEffects = append(p.Stats.TimedEffects, effect)
+}
+
+// Called every minute by the world clock, returns effects that expired
+func (p *Player) TickTimedEffects() []types.ActionEffect {
+	keep := make([]types.ActionEffect, 0)
+	expired := make([]types.ActionEffect, 0)
+
+	for _, effect := range p.Stats.TimedEffects {
+		effect.Duration--
+
+		if effect.Duration <= 0 {
+			expired = append(expired, effect)
+			continue
+		}
+
+		keep = append(keep, effect)
+	}
+
+	p.Stats.TimedEffects = keep
+
+	return expired
+}
+
 func (p *Player) GetSkillPath() types.SkillPath {
 	counts := make(map[types.SkillPath]int)
 
@@ -831,16 +1149,22 @@ func NewPlayer(name string, uid string) Player {
 		name,
 		PlayerXP{Level: 1},
 		PlayerStats{
-			HP:          data.PlayerDefaults.Stats[types.STAT_HP],
-			Effects:     make([]types.ActionEffect, 0),
-			CurrentMana: data.PlayerDefaults.Stats[types.STAT_MANA],
+			HP:           data.PlayerDefaults.Stats[types.STAT_HP],
+			Effects:      make([]types.ActionEffect, 0),
+			CurrentMana:  data.PlayerDefaults.Stats[types.STAT_MANA],
+			TimedEffects: make([]types.ActionEffect, 0),
 		},
 		PlayerMeta{
-			OwnUUID: uuid.New(),
-			UserID:  uid,
+			OwnUUID:        uuid.New(),
+			UserID:         uid,
+			UnlockedFloors: make([]string, 0),
+			Location:       GetStartLocation(),
 		},
 		inventory.GetDefaultInventory(),
-		data.PlayerDefaults.Level,
+		maps.Clone(data.PlayerDefaults.Level),
 		data.PlayerDefaults.Stats,
+		NewQuestLog(),
+		PvPStats{},
+		nil,
 	}
 }
@@ -0,0 +1,89 @@
+package player
+
+import (
+	"errors"
+	"sao/data"
+	"sao/types"
+	"sao/world/party"
+	"slices"
+)
+
+func (p *Player) CanTakePartyRole(role party.PartyRole) error {
+	roleDef, exists := data.PartyConfig.Roles[string(role)]
+
+	if !exists {
+		return errors.New("ROLE_NOT_FOUND")
+	}
+
+	if p.XP.Level < roleDef.MinLevel {
+		return errors.New("LEVEL_TOO_LOW")
+	}
+
+	if roleDef.Path != nil && p.GetSkillPath() != *roleDef.Path {
+		return errors.New("WRONG_PATH")
+	}
+
+	return nil
+}
+
+// Active synergies for given party composition
+func GetPartySynergies(roles []party.PartyRole) []types.PartySynergy {
+	active := make([]types.PartySynergy, 0)
+
+	for _, synergy := range data.PartyConfig.Synergies {
+		complete := true
+
+		for _, role := range synergy.Roles {
+			if !slices.Contains(roles, party.PartyRole(role)) {
+				complete = false
+				break
+			}
+		}
+
+		if complete {
+			active = append(active, synergy)
+		}
+	}
+
+	return active
+}
+
+func (p *Player) GetPartyEffects() []types.ActionEffect {
+	effects := make([]types.ActionEffect, 0)
+
+	if p.Meta.Party == nil {
+		return effects
+	}
+
+	otherMembers := p.Meta.Party.MembersCount - 1
+	bonuses := make([]types.PartyBonus, 0)
+
+	if roleDef, exists := data.PartyConfig.Roles[string(p.Meta.Party.Role)]; exists {
+		bonuses = append(bonuses, roleDef.Bonuses...)
+
+		if roleDef.TauntMembers > 0 && otherMembers >= roleDef.TauntMembers {
+			effects = append(effects, types.ActionEffect{Effect: types.EFFECT_TAUNT, Duration: -1})
+		}
+	}
+
+	for _, synergy := range GetPartySynergies(p.Meta.Party.Roles) {
+		bonuses = append(bonuses, synergy.Bonuses...)
+	}
+
+	for _, bonus := range bonuses {
+		value := bonus.Value + otherMembers*bonus.PerMember
+
+		if value == 0 {
+			continue
+		}
+
+		effects = append(effects, types.ActionEffect{
+			Effect:   types.EFFECT_STAT_INC,
+			Duration: -1,
+			Value:    value,
+			Meta:     types.ActionEffectStat{Stat: bonus.Stat, IsPercent: bonus.Percent},
+		})
+	}
+
+	return effects
+}
@@ -0,0 +1,68 @@
+package player
+
+import (
+	"sao/types"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+type PvPStats struct {
+	Wins     int
+	Losses   int
+	GoldWon  int
+	GoldLost int
+	//Won in tournaments, each one is kept once
+	Titles []string
+}
+
+func (s PvPStats) Serialize() map[string]any {
+	return map[string]any{
+		"wins":      s.Wins,
+		"losses":    s.Losses,
+		"gold_won":  s.GoldWon,
+		"gold_lost": s.GoldLost,
+		"titles":    s.Titles,
+	}
+}
+
+func DeserializePvPStats(rawData any) PvPStats {
+	data, ok := rawData.(map[string]any)
+
+	if !ok {
+		return PvPStats{}
+	}
+
+	stats := PvPStats{
+		Wins:     int(data["wins"].(float64)),
+		Losses:   int(data["losses"].(float64)),
+		GoldWon:  int(data["gold_won"].(float64)),
+		GoldLost: int(data["gold_lost"].(float64)),
+		Titles:   make([]string, 0),
+	}
+
+	if rawTitles, ok := data["titles"].([]any); ok {
+		for _, title := range rawTitles {
+			stats.Titles = append(stats.Titles, title.(string))
+		}
+	}
+
+	return stats
+}
+
+func (p *Player) ReceiveTournamentPrize(prize types.TournamentPrize) {
+	for _, reward := range prize.Rewards {
+		switch reward.Type {
+		case types.LOOT_EXP:
+			p.AddEXP(reward.Count)
+		case types.LOOT_GOLD:
+			p.AddGold(reward.Count)
+		case types.LOOT_ITEM:
+			p.GiveItem(uuid.MustParse(reward.Item), reward.Count)
+		}
+	}
+
+	if prize.Title != "" && !slices.Contains(p.PvP.Titles, prize.Title) {
+		p.PvP.Titles = append(p.PvP.Titles, prize.Title)
+	}
+}
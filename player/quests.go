@@ -0,0 +1,247 @@
+package player
+
+import (
+	"errors"
+	"sao/types"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+type QuestLog struct {
+	//Progress per objective, only for objectives tracked by events
+	Active    map[uuid.UUID][]int
+	Completed []uuid.UUID
+}
+
+func NewQuestLog() QuestLog {
+	return QuestLog{
+		Active:    make(map[uuid.UUID][]int),
+		Completed: make([]uuid.UUID, 0),
+	}
+}
+
+func (q QuestLog) Serialize() map[string]any {
+	active := make(map[string]any)
+
+	for questUuid, progress := range q.Active {
+		active[questUuid.String()] = progress
+	}
+
+	completed := make([]string, 0)
+
+	for _, questUuid := range q.Completed {
+		completed = append(completed, questUuid.String())
+	}
+
+	return map[string]any{"active": active, "completed": completed}
+}
+
+func DeserializeQuestLog(rawData any) QuestLog {
+	log := NewQuestLog()
+
+	data, ok := rawData.(map[string]any)
+
+	if !ok {
+		return log
+	}
+
+	if active, ok := data["active"].(map[string]any); ok {
+		for rawUuid, rawProgress := range active {
+			questUuid, err := uuid.Parse(rawUuid)
+
+			if err != nil {
+				continue
+			}
+
+			progress := make([]int, 0)
+
+			for _, value := range rawProgress.([]any) {
+				progress = append(progress, int(value.(float64)))
+			}
+
+			log.Active[questUuid] = progress
+		}
+	}
+
+	if completed, ok := data["completed"].([]any); ok {
+		for _, rawUuid := range completed {
+			if questUuid, err := uuid.Parse(rawUuid.(string)); err == nil {
+				log.Completed = append(log.Completed, questUuid)
+			}
+		}
+	}
+
+	return log
+}
+
+func (q QuestLog) HasCompleted(questUuid uuid.UUID) bool {
+	return slices.Contains(q.Completed, questUuid)
+}
+
+func (p *Player) AcceptQuest(quest types.Quest, location *types.Location) error {
+	if location == nil || location.Name != quest.Location {
+		return errors.New("WRONG_LOCATION")
+	}
+
+	if _, active := p.Quests.Active[quest.UUID]; active {
+		return errors.New("QUEST_ALREADY_ACTIVE")
+	}
+
+	if p.Quests.HasCompleted(quest.UUID) {
+		return errors.New("QUEST_ALREADY_COMPLETED")
+	}
+
+	if p.XP.Level < quest.MinLevel {
+		return errors.New("PLAYER_LVL_TOO_LOW")
+	}
+
+	p.Quests.Active[quest.UUID] = make([]int, len(quest.Objectives))
+
+	return nil
+}
+
+// Returns current progress of each objective, capped at the objective count
+func (p *Player) GetQuestProgress(quest types.Quest) []int {
+	tracked, active := p.Quests.Active[quest.UUID]
+	progress := make([]int, len(quest.Objectives))
+
+	for idx, objective := range quest.Objectives {
+		switch objective.Type {
+		case types.QUEST_LEVEL:
+			progress[idx] = p.XP.Level
+		case types.QUEST_DELIVER:
+			progress[idx] = p.Inventory.GetItemCount(uuid.MustParse(objective.Target))
+		default:
+			if active && idx < len(tracked) {
+				progress[idx] = tracked[idx]
+			}
+		}
+
+		if progress[idx] > objective.Count {
+			progress[idx] = objective.Count
+		}
+	}
+
+	return progress
+}
+
+func (p *Player) IsQuestDone(quest types.Quest) bool {
+	for idx, value := range p.GetQuestProgress(quest) {
+		if value < quest.Objectives[idx].Count {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Returns uuids of quests that progressed
+func (p *Player) RecordQuestEvent(quests map[uuid.UUID]types.Quest, check func(types.QuestObjective) int) []uuid.UUID {
+	changed := make([]uuid.UUID, 0)
+
+	for questUuid, progress := range p.Quests.Active {
+		quest, exists := quests[questUuid]
+
+		if !exists {
+			continue
+		}
+
+		progressed := false
+
+		for idx, objective := range quest.Objectives {
+			if idx >= len(progress) || progress[idx] >= objective.Count {
+				continue
+			}
+
+			if value := check(objective); value > 0 {
+				progress[idx] += value
+
+				if progress[idx] > objective.Count {
+					progress[idx] = objective.Count
+				}
+
+				progressed = true
+			}
+		}
+
+		if progressed {
+			changed = append(changed, questUuid)
+		}
+	}
+
+	return changed
+}
+
+func (p *Player) RecordKills(quests map[uuid.UUID]types.Quest, mobIds []string, location string) []uuid.UUID {
+	return p.RecordQuestEvent(quests, func(objective types.QuestObjective) int {
+		if objective.Type != types.QUEST_KILL {
+			return 0
+		}
+
+		if objective.Location != "" && objective.Location != location {
+			return 0
+		}
+
+		count := 0
+
+		for _, mobId := range mobIds {
+			if mobId == objective.Target {
+				count++
+			}
+		}
+
+		return count
+	})
+}
+
+func (p *Player) RecordTournamentWin(quests map[uuid.UUID]types.Quest) []uuid.UUID {
+	return p.RecordQuestEvent(quests, func(objective types.QuestObjective) int {
+		if objective.Type == types.QUEST_TOURNAMENT_WIN {
+			return 1
+		}
+
+		return 0
+	})
+}
+
+func (p *Player) CompleteQuest(quest types.Quest) error {
+	if _, active := p.Quests.Active[quest.UUID]; !active {
+		return errors.New("QUEST_NOT_ACTIVE")
+	}
+
+	if !p.IsQuestDone(quest) {
+		return errors.New("QUEST_NOT_DONE")
+	}
+
+	toDeliver := make([]types.WithCount[uuid.UUID], 0)
+
+	for _, objective := range quest.Objectives {
+		if objective.Type == types.QUEST_DELIVER {
+			toDeliver = append(toDeliver, types.WithCount[uuid.UUID]{
+				Item: uuid.MustParse(objective.Target), Count: objective.Count,
+			})
+		}
+	}
+
+	if err := p.Inventory.RemoveItems(toDeliver); err != nil {
+		return err
+	}
+
+	for _, reward := range quest.Rewards {
+		switch reward.Type {
+		case types.LOOT_EXP:
+			p.AddEXP(reward.Count)
+		case types.LOOT_GOLD:
+			p.AddGold(reward.Count)
+		case types.LOOT_ITEM:
+			p.GiveItem(uuid.MustParse(reward.Item), reward.Count)
+		}
+	}
+
+	delete(p.Quests.Active, quest.UUID)
+
+	p.Quests.Completed = append(p.Quests.Completed, quest.UUID)
+
+	return nil
+}
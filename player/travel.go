@@ -0,0 +1,137 @@
+package player
+
+import (
+	"errors"
+	"sao/data"
+	"sao/types"
+)
+
+type PlayerTravel struct {
+	Destination types.EntityLocation
+	//In minutes
+	Remaining int
+}
+
+func GetStartLocation() types.EntityLocation {
+	return types.EntityLocation{
+		Floor:    data.WorldConfig.StartFloor,
+		Location: data.FloorMap[data.WorldConfig.StartFloor].Default,
+	}
+}
+
+// Returns nil while travelling
+func (p *Player) GetLocation() *types.Location {
+	if p.Meta.Travel != nil {
+		return nil
+	}
+
+	return data.FloorMap.GetLocation(p.Meta.Location)
+}
+
+// Returns travel time in minutes, -1 if locations aren't connected
+func GetTravelTime(from types.EntityLocation, to types.EntityLocation) int {
+	fromLocation := data.FloorMap.GetLocation(from)
+	toLocation := data.FloorMap.GetLocation(to)
+
+	if fromLocation == nil || toLocation == nil {
+		return -1
+	}
+
+	for _, connection := range fromLocation.Connections {
+		if connection.Floor == to.Floor && connection.Location == to.Location {
+			return connection.Time
+		}
+	}
+
+	for _, connection := range toLocation.Connections {
+		if connection.Floor == from.Floor && connection.Location == from.Location {
+			return connection.Time
+		}
+	}
+
+	if from.Floor == to.Floor {
+		return data.WorldConfig.TravelTime
+	}
+
+	return -1
+}
+
+// Returns travel time in minutes, 0 for teleports
+func (p *Player) StartTravel(destination types.EntityLocation, teleport bool) (int, error) {
+	if p.Meta.FightInstance != nil {
+		return 0, errors.New("PLAYER_IN_FIGHT")
+	}
+
+	if p.GetCurrentHP() <= 0 {
+		return 0, errors.New("PLAYER_DEAD")
+	}
+
+	if p.Meta.Travel != nil {
+		return 0, errors.New("ALREADY_TRAVELING")
+	}
+
+	toLocation := data.FloorMap.GetLocation(destination)
+
+	if toLocation == nil {
+		return 0, errors.New("LOCATION_NOT_FOUND")
+	}
+
+	if destination == p.Meta.Location {
+		return 0, errors.New("SAME_LOCATION")
+	}
+
+	if !p.IsFloorUnlocked(data.FloorMap[destination.Floor]) {
+		return 0, errors.New("FLOOR_LOCKED")
+	}
+
+	if teleport {
+		fromLocation := data.FloorMap.GetLocation(p.Meta.Location)
+
+		if fromLocation == nil || !fromLocation.TP || !toLocation.TP {
+			return 0, errors.New("NOT_TP_LOCATION")
+		}
+
+		if p.Inventory.Gold < data.WorldConfig.TeleportCost {
+			return 0, errors.New("NOT_ENOUGH_GOLD")
+		}
+
+		p.Inventory.Gold -= data.WorldConfig.TeleportCost
+		p.Meta.Location = destination
+
+		return 0, nil
+	}
+
+	travelTime := GetTravelTime(p.Meta.Location, destination)
+
+	if travelTime < 0 {
+		return 0, errors.New("NOT_CONNECTED")
+	}
+
+	if travelTime == 0 {
+		p.Meta.Location = destination
+
+		return 0, nil
+	}
+
+	p.Meta.Travel = &PlayerTravel{Destination: destination, Remaining: travelTime}
+
+	return travelTime, nil
+}
+
+// Called every minute by the world clock, returns true on arrival
+func (p *Player) TickTravel() bool {
+	if p.Meta.Travel == nil {
+		return false
+	}
+
+	p.Meta.Travel.Remaining--
+
+	if p.Meta.Travel.Remaining > 0 {
+		return false
+	}
+
+	p.Meta.Location = p.Meta.Travel.Destination
+	p.Meta.Travel = nil
+
+	return true
+}
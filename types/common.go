@@ -112,11 +112,14 @@ type LootType int
 const (
 	LOOT_EXP LootType = iota
 	LOOT_GOLD
+	LOOT_ITEM
 )
 
 type Loot struct {
 	Type  LootType
 	Count int
+	//Only for LOOT_ITEM
+	Item string `parts:"Item,ignoreEmpty"`
 }
 
 type ActionEnum int
@@ -132,6 +135,8 @@ const (
 	ACTION_EFFECT
 	ACTION_DMG
 	ACTION_SUMMON
+	//Ends player turn without doing anything
+	ACTION_SKIP
 )
 
 type Action struct {
@@ -257,6 +262,9 @@ type PlayerEntity interface {
 	GetLevelSkillMeta(int) any
 
 	UseItem(uuid.UUID, Entity, FightInstance)
+
+	//Duration in minutes, ticked by the world clock
+	ApplyTimedEffect(ActionEffect)
 }
 
 type NPCStore struct {
@@ -0,0 +1,14 @@
+package types
+
+import "github.com/google/uuid"
+
+type Recipe struct {
+	UUID        uuid.UUID
+	Name        string
+	Description string
+	//Location flag required to craft, empty means anywhere
+	Location string
+	Gold     int
+	Inputs   []WithCount[uuid.UUID] `parts:"PartsInputs,ignoreEmpty"`
+	Output   WithCount[uuid.UUID]   `parts:"PartsOutput,ignoreEmpty"`
+}
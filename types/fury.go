@@ -0,0 +1,35 @@
+package types
+
+import "github.com/google/uuid"
+
+type FuryRequirementType int
+
+const (
+	//Fury level, Count is the level
+	FURY_REQ_LEVEL FuryRequirementType = iota
+	//Item uuid as Target, consumed on tier up
+	FURY_REQ_ITEM
+	//Mob id as Target, only kills made with the fury bound count
+	FURY_REQ_KILL
+)
+
+type FuryRequirement struct {
+	Type   FuryRequirementType
+	Target string
+	Count  int
+}
+
+type FuryTier struct {
+	Stats  map[Stat]int
+	Skills []PlayerSkill
+	//Needed to unlock this tier, first tier is given on binding
+	Requirements []FuryRequirement
+}
+
+type FuryDefinition struct {
+	UUID        uuid.UUID
+	Name        string
+	Description string
+	Tiers       []FuryTier                           `parts:"PartsTiers,ignoreEmpty"`
+	LvlStats    func(lvl int, tier int) map[Stat]int `parts:"PartsLvlStats,ignoreEmpty"`
+}
@@ -0,0 +1,15 @@
+package types
+
+type GuildBonus struct {
+	Stat    Stat
+	Value   int
+	Percent bool
+}
+
+// Bonuses of all reached levels are applied to every member
+type GuildLevel struct {
+	//Total guild exp needed to reach the level
+	XP         int
+	MaxMembers int
+	Bonuses    []GuildBonus
+}
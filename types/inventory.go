@@ -1,6 +1,8 @@
 package types
 
 import (
+	"fmt"
+
 	"github.com/disgoorg/disgo/discord"
 	"github.com/disgoorg/disgo/events"
 	"github.com/google/uuid"
@@ -148,32 +150,115 @@ type PlayerItem struct {
 	TakesSlot   bool `parts:"TakesSlot,ignoreEmpty"`
 	Stacks      bool `parts:"Stacks,ignoreEmpty"`
 	Consume     bool `parts:"Consume,ignoreEmpty"`
+	OutOfFight  bool `parts:"OutOfFight,ignoreEmpty"`
 	Count       int
 	MaxCount    int
 	Hidden      bool `parts:"Hidden,ignoreEmpty"`
 	Stats       map[Stat]int `parts:"PartsStats,ignoreEmpty"`
 	DerivedStats []DerivedStat `parts:"PartsDerivedStats,ignoreEmpty"`
 	Effects     []PlayerSkill `parts:"EffectsList,ignoreEmpty"`
+	//UUID of the fury bound to player on use
+	Fury string `parts:"Fury,ignoreEmpty"`
+	//Per instance data, not part of the template
+	Instance uuid.UUID   `parts:"PartsInstance,ignoreEmpty"`
+	Rarity   Rarity      `parts:"PartsRarity,ignoreEmpty"`
+	Affixes  []ItemAffix `parts:"PartsAffixes,ignoreEmpty"`
+}
+
+func (item *PlayerItem) GetStat(stat Stat) int {
+	value := item.Stats[stat]
+
+	for _, affix := range item.Affixes {
+		if affix.Derived == nil && affix.Stat == stat {
+			value += affix.Value
+		}
+	}
+
+	return value
+}
+
+func (item *PlayerItem) GetDerivedStats() []DerivedStat {
+	derived := make([]DerivedStat, 0)
+
+	derived = append(derived, item.DerivedStats...)
+
+	for _, affix := range item.Affixes {
+		if affix.Derived != nil {
+			derived = append(derived, *affix.Derived)
+		}
+	}
+
+	return derived
+}
+
+func (item *PlayerItem) DisplayName() string {
+	if item.Stacks {
+		return item.Name
+	}
+
+	return fmt.Sprintf("%s %s", RarityToEmoji[item.Rarity], item.Name)
+}
+
+type Rarity int
+
+const (
+	RARITY_COMMON Rarity = iota
+	RARITY_UNCOMMON
+	RARITY_RARE
+	RARITY_EPIC
+	RARITY_LEGENDARY
+)
+
+var RarityToString = map[Rarity]string{
+	RARITY_COMMON:    "Zwykły",
+	RARITY_UNCOMMON:  "Niezwykły",
+	RARITY_RARE:      "Rzadki",
+	RARITY_EPIC:      "Epicki",
+	RARITY_LEGENDARY: "Legendarny",
+}
+
+var RarityToColor = map[Rarity]int{
+	RARITY_COMMON:    0xffffff,
+	RARITY_UNCOMMON:  0x1eff00,
+	RARITY_RARE:      0x0070dd,
+	RARITY_EPIC:      0xa335ee,
+	RARITY_LEGENDARY: 0xff8000,
 }
 
+var RarityToEmoji = map[Rarity]string{
+	RARITY_COMMON:    "⚪",
+	RARITY_UNCOMMON:  "🟢",
+	RARITY_RARE:      "🔵",
+	RARITY_EPIC:      "🟣",
+	RARITY_LEGENDARY: "🟠",
+}
+
+type ItemAffix struct {
+	Stat  Stat
+	Value int
+	//Set for derived stat bonuses, Stat and Value are ignored then
+	Derived *DerivedStat
+}
+
+// Fight is nil when the item is used outside of combat (from the backpack)
 func (item *PlayerItem) UseItem(owner PlayerEntity, target Entity, fight FightInstance) {
 	if item.Count < 0 {
 		return
 	}
 
-	if item.Consume {
-		item.Count--
-	}
-
 	for _, effect := range item.Effects {
 		if effect.GetTrigger().Type == TRIGGER_PASSIVE {
 			continue
 		}
 
-		effect.Execute(owner, target, fight, nil)
+		effect.Execute(owner, target, fight, ItemUsageMeta{InFight: fight != nil})
 	}
 }
 
+type ItemUsageMeta struct {
+	InFight bool
+}
+
 type WithCount[T any] struct {
 	Item  T
 	Count int
@@ -8,6 +8,26 @@ type Location struct {
 	Enemies  []EnemyMeta `parts:"Enemies,ignoreEmpty"`
 	Flags    []string `parts:"Flags,ignoreEmpty"`
 	Unlocked bool
+	//Locations on the same floor are always connected, this is for custom travel times and other floors
+	Connections []LocationConnection `parts:"Connections,ignoreEmpty"`
+	//Rolled on search, Enemies are used if empty or with the "picker" flag
+	Encounters []Encounter `parts:"Encounters,ignoreEmpty"`
+}
+
+type Encounter struct {
+	Weight int
+	Mobs   []EnemyMeta
+	Rare   bool
+	//Mobs get Scaling% stats for every player level above Level
+	Level   int
+	Scaling int
+}
+
+type LocationConnection struct {
+	Floor    string
+	Location string
+	//In minutes
+	Time int
 }
 
 type EnemyMeta struct {
@@ -24,6 +44,9 @@ type Floor struct {
 	Flags            []string `parts:"Flags,ignoreEmpty"`
 	Unlocked         bool
 	CountsAsUnlocked bool
+	//Mob id, first defeat unlocks the Next floor
+	Boss string `parts:"Boss,ignoreEmpty"`
+	Next string `parts:"Next,ignoreEmpty"`
 }
 
 func (f Floor) FindLocation(str string) *Location {
@@ -0,0 +1,27 @@
+package types
+
+type PartyBonus struct {
+	Stat  Stat
+	Value int
+	//Added for every other party member
+	PerMember int
+	Percent   bool
+}
+
+type PartyRoleDefinition struct {
+	Id       string
+	Name     string
+	MinLevel int
+	//Skill path player has to follow, nil for any
+	Path    *SkillPath
+	Bonuses []PartyBonus
+	//Role taunts enemies when party has at least that many other members, 0 never
+	TauntMembers int
+}
+
+// Applied to every member when each of the roles is taken by someone in the party
+type PartySynergy struct {
+	Name    string
+	Roles   []string
+	Bonuses []PartyBonus
+}
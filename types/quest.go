@@ -0,0 +1,34 @@
+package types
+
+import "github.com/google/uuid"
+
+type QuestObjectiveType int
+
+const (
+	QUEST_KILL QuestObjectiveType = iota
+	QUEST_DELIVER
+	QUEST_LEVEL
+	QUEST_TOURNAMENT_WIN
+)
+
+type QuestObjective struct {
+	Type QuestObjectiveType
+	//Mob id for QUEST_KILL, item uuid for QUEST_DELIVER
+	Target string `parts:"Target,ignoreEmpty"`
+	//Location name for QUEST_KILL, empty means anywhere
+	Location string `parts:"Location,ignoreEmpty"`
+	Count    int
+}
+
+type Quest struct {
+	UUID        uuid.UUID
+	Name        string
+	Description string
+	//NPC offering the quest
+	Giver string
+	//Location name where the NPC can be found
+	Location   string
+	MinLevel   int              `parts:"MinLevel,ignoreEmpty"`
+	Objectives []QuestObjective `parts:"Objectives"`
+	Rewards    []Loot           `parts:"Rewards"`
+}
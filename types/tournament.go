@@ -0,0 +1,14 @@
+package types
+
+// Every participant on the given place gets the prize, places are shared on ties
+type TournamentPrize struct {
+	Place   int
+	Rewards []Loot
+	//Empty when place gives no title
+	Title string
+}
+
+type TournamentPrizePool struct {
+	Name   string
+	Prizes []TournamentPrize
+}
@@ -0,0 +1,22 @@
+package types
+
+import "github.com/google/uuid"
+
+type WorldBoss struct {
+	UUID uuid.UUID
+	Name string
+	//Mob id
+	Mob   string
+	Count int
+	//Floor and location names
+	Floor    string
+	Location string
+	//Minutes between spawns
+	Interval int
+	//Minutes players have to join after the announcement
+	JoinWindow int
+	//Boss gets Scaling% stats for every participant after the first one
+	Scaling int
+	//Added to the mob loot, split by damage dealt
+	Loot []Loot `parts:"Loot,ignoreEmpty"`
+}
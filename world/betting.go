@@ -0,0 +1,272 @@
+package world
+
+import (
+	"errors"
+	"fmt"
+	"sao/data"
+	"sao/player"
+	"sao/world/tournament"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/google/uuid"
+)
+
+// Gold is held by the world until the match is resolved
+type TournamentBet struct {
+	Tournament uuid.UUID
+	Stage      int
+	Match      int
+	Bettor     uuid.UUID
+	Pick       uuid.UUID
+	Amount     int
+	//Payout in percent of the amount, fixed when the bet is placed
+	Odds int
+}
+
+func (b *TournamentBet) Serialize() map[string]any {
+	return map[string]any{
+		"tournament": b.Tournament.String(),
+		"stage":      b.Stage,
+		"match":      b.Match,
+		"bettor":     b.Bettor.String(),
+		"pick":       b.Pick.String(),
+		"amount":     b.Amount,
+		"odds":       b.Odds,
+	}
+}
+
+func DeserializeTournamentBet(rawData map[string]any) *TournamentBet {
+	return &TournamentBet{
+		Tournament: uuid.MustParse(rawData["tournament"].(string)),
+		Stage:      int(rawData["stage"].(float64)),
+		Match:      int(rawData["match"].(float64)),
+		Bettor:     uuid.MustParse(rawData["bettor"].(string)),
+		Pick:       uuid.MustParse(rawData["pick"].(string)),
+		Amount:     int(rawData["amount"].(float64)),
+		Odds:       int(rawData["odds"].(float64)),
+	}
+}
+
+// Average level of players that would fight for the participant, 0 when nobody can
+func (w *World) participantLevel(tournamentObj *tournament.Tournament, participant uuid.UUID) float64 {
+	members := []uuid.UUID{participant}
+
+	if tournamentObj.IsTeamMode() {
+		members = tournamentObj.GetLineup(participant, func(playerUuid uuid.UUID) bool {
+			_, exists := w.Players[playerUuid]
+
+			return exists
+		})
+	}
+
+	total, count := 0, 0
+
+	for _, member := range members {
+		if pl, exists := w.Players[member]; exists {
+			total += pl.XP.Level
+			count++
+		}
+	}
+
+	if count == 0 {
+		return 0
+	}
+
+	return float64(total) / float64(count)
+}
+
+// Odds for betting on each side of the match
+func (w *World) GetMatchOdds(tournamentObj *tournament.Tournament, match *tournament.TournamentMatch) [2]int {
+	levels := [2]float64{
+		w.participantLevel(tournamentObj, match.Players[0]),
+		w.participantLevel(tournamentObj, match.Players[1]),
+	}
+
+	return [2]int{
+		tournament.MatchOdds(levels[0], levels[1], data.TournamentConfig.BetLevelScale, data.TournamentConfig.BetMargin),
+		tournament.MatchOdds(levels[1], levels[0], data.TournamentConfig.BetLevelScale, data.TournamentConfig.BetMargin),
+	}
+}
+
+type BetOption struct {
+	Match int
+	Side  int
+	Name  string
+	Odds  int
+}
+
+// Sides of current stage matches that still take bets
+func (w *World) GetBetOptions(tournamentObj *tournament.Tournament) []BetOption {
+	options := make([]BetOption, 0)
+
+	stage := tournamentObj.GetCurrentStage()
+
+	if tournamentObj.State != tournament.Running || stage == nil {
+		return options
+	}
+
+	for matchIdx, match := range stage.Matches {
+		if match.IsBye() || match.State != tournament.BeforeMatch {
+			continue
+		}
+
+		odds := w.GetMatchOdds(tournamentObj, match)
+
+		for side, participant := range match.Players {
+			options = append(options, BetOption{
+				Match: matchIdx,
+				Side:  side,
+				Name:  w.tournamentPlayerName(tournamentObj, participant),
+				Odds:  odds[side],
+			})
+		}
+	}
+
+	return options
+}
+
+// Bets are taken on matches of the current stage that didn't start yet, participants can't bet
+func (w *World) PlaceBet(tUuid uuid.UUID, matchIdx int, side int, p *player.Player, amount int) (*TournamentBet, error) {
+	tournamentObj := w.Tournaments[tUuid]
+
+	if tournamentObj == nil {
+		return nil, errors.New("TOURNAMENT_NOT_FOUND")
+	}
+
+	stage := tournamentObj.GetCurrentStage()
+
+	if tournamentObj.State != tournament.Running || stage == nil {
+		return nil, errors.New("TOURNAMENT_NOT_RUNNING")
+	}
+
+	if matchIdx < 0 || matchIdx >= len(stage.Matches) || side < 0 || side > 1 {
+		return nil, errors.New("MATCH_NOT_FOUND")
+	}
+
+	match := stage.Matches[matchIdx]
+
+	if match.IsBye() || match.State != tournament.BeforeMatch {
+		return nil, errors.New("MATCH_STARTED")
+	}
+
+	if tournamentObj.GetParticipant(p.GetUUID()) != uuid.Nil {
+		return nil, errors.New("PARTICIPANT_CANNOT_BET")
+	}
+
+	for _, bet := range w.Bets {
+		if bet.Tournament == tUuid && bet.Stage == stage.IDX && bet.Match == matchIdx && bet.Bettor == p.GetUUID() {
+			return nil, errors.New("ALREADY_BET")
+		}
+	}
+
+	if amount < data.TournamentConfig.BetMin {
+		return nil, errors.New("BET_TOO_LOW")
+	}
+
+	if amount > data.TournamentConfig.BetMax {
+		return nil, errors.New("BET_TOO_HIGH")
+	}
+
+	if p.Inventory.Gold < amount {
+		return nil, errors.New("NOT_ENOUGH_GOLD")
+	}
+
+	p.Inventory.Gold -= amount
+
+	bet := &TournamentBet{
+		Tournament: tUuid,
+		Stage:      stage.IDX,
+		Match:      matchIdx,
+		Bettor:     p.GetUUID(),
+		Pick:       match.Players[side],
+		Amount:     amount,
+		Odds:       w.GetMatchOdds(tournamentObj, match)[side],
+	}
+
+	w.Bets[uuid.New()] = bet
+
+	return bet, nil
+}
+
+// Bet can still be settled, its match wasn't played yet
+func (w *World) isBetOpen(bet *TournamentBet) bool {
+	tournamentObj := w.Tournaments[bet.Tournament]
+
+	if tournamentObj == nil || tournamentObj.State != tournament.Running || bet.Stage >= len(tournamentObj.Stages) {
+		return false
+	}
+
+	matches := tournamentObj.Stages[bet.Stage].Matches
+
+	return bet.Match < len(matches) && matches[bet.Match].State != tournament.FinishedMatch
+}
+
+// Pays out bets on the resolved match, lost stakes stay with the world
+func (w *World) SettleBets(tUuid uuid.UUID, stageIdx int, matchIdx int, winner uuid.UUID) {
+	tournamentObj := w.Tournaments[tUuid]
+
+	for betUuid, bet := range w.Bets {
+		if bet.Tournament != tUuid || bet.Stage != stageIdx || bet.Match != matchIdx {
+			continue
+		}
+
+		delete(w.Bets, betUuid)
+
+		pl, exists := w.Players[bet.Bettor]
+
+		if !exists {
+			continue
+		}
+
+		if bet.Pick != winner {
+			w.SendMessage(
+				pl.Meta.UserID,
+				discord.NewMessageCreateBuilder().
+					SetContentf("Przegrany zakład w turnieju `%v`, stracono %v złota", tournamentObj.Name, bet.Amount).
+					Build(),
+				true,
+			)
+
+			continue
+		}
+
+		payout := bet.Amount * bet.Odds / 100
+
+		pl.AddGold(payout)
+
+		w.SendMessage(
+			pl.Meta.UserID,
+			discord.NewMessageCreateBuilder().
+				SetContentf("Wygrany zakład w turnieju `%v`! Otrzymano %v złota", tournamentObj.Name, payout).
+				Build(),
+			true,
+		)
+	}
+}
+
+// Returns stakes of bets matching the filter, used for cancelled matches
+func (w *World) RefundBets(filter func(bet *TournamentBet) bool, reason string) {
+	for betUuid, bet := range w.Bets {
+		if !filter(bet) {
+			continue
+		}
+
+		delete(w.Bets, betUuid)
+
+		pl, exists := w.Players[bet.Bettor]
+
+		if !exists {
+			continue
+		}
+
+		pl.AddGold(bet.Amount)
+
+		w.SendMessage(
+			pl.Meta.UserID,
+			discord.NewMessageCreateBuilder().
+				SetContent(fmt.Sprintf("Zwrócono zakład (%v złota): %v", bet.Amount, reason)).
+				Build(),
+			true,
+		)
+	}
+}
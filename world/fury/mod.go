@@ -1,23 +1,26 @@
 package fury
 
 import (
+	"errors"
+	"maps"
+	"sao/data"
 	"sao/types"
 
 	"github.com/google/uuid"
 )
 
 type Fury struct {
+	UUID        uuid.UUID
 	Name        string
 	Master      *uuid.UUID
-	Tiers       []FuryTier
+	Tiers       []types.FuryTier
 	CurrentTier int
 	XP          FuryXP
 	LvlStats    func(lvl int, tier int) map[types.Stat]int
-}
-
-type FuryTier struct {
-	Stats       map[types.Stat]int
-	Skills      []types.PlayerSkill
+	//Mob ids killed since binding, used by tier up requirements
+	Kills map[string]int
+	//Computed on level, tier and template changes, lookups happen every turn
+	stats map[types.Stat]int
 }
 
 type FuryXP struct {
@@ -25,11 +28,37 @@ type FuryXP struct {
 	LVL int
 }
 
+// Instances copy template data from data.Furies and only keep progress
+func New(furyUuid uuid.UUID, master uuid.UUID) (*Fury, error) {
+	template, exists := data.Furies[furyUuid]
+
+	if !exists {
+		return nil, errors.New("FURY_NOT_FOUND")
+	}
+
+	fury := &Fury{
+		UUID:        template.UUID,
+		Name:        template.Name,
+		Master:      &master,
+		Tiers:       template.Tiers,
+		CurrentTier: 1,
+		XP:          FuryXP{XP: 0, LVL: 1},
+		LvlStats:    template.LvlStats,
+		Kills:       make(map[string]int),
+	}
+
+	fury.refreshStats()
+
+	return fury, nil
+}
+
 func (f *Fury) NextLvlXPGauge() int {
 	return f.CurrentTier*1000 + f.XP.LVL*100
 }
 
 func (f *Fury) AddXP(xp int) {
+	before := f.XP.LVL
+
 	if f.XP.LVL == 10 {
 		f.XP.XP = 0
 	} else {
@@ -37,16 +66,44 @@ func (f *Fury) AddXP(xp int) {
 	}
 
 	for f.XP.XP >= f.NextLvlXPGauge() && f.XP.LVL < 10 {
-		f.XP.LVL++
 		f.XP.XP -= f.NextLvlXPGauge()
+		f.XP.LVL++
 	}
 
 	if f.XP.LVL == 10 {
 		f.XP.XP = 0
 	}
+
+	if f.XP.LVL != before {
+		f.refreshStats()
+	}
 }
 
-func (f *Fury) GetStats() map[types.Stat]int {
+func (f *Fury) RecordKills(mobIds []string) {
+	for _, mobId := range mobIds {
+		f.Kills[mobId]++
+	}
+}
+
+// Returns nil when fury is already at max tier
+func (f *Fury) GetNextTier() *types.FuryTier {
+	if f.CurrentTier >= len(f.Tiers) {
+		return nil
+	}
+
+	return &f.Tiers[f.CurrentTier]
+}
+
+// Tier up resets level, items are checked and taken by the master
+func (f *Fury) TierUp() {
+	f.CurrentTier++
+	f.XP = FuryXP{XP: 0, LVL: 1}
+
+	f.refreshStats()
+}
+
+// Tiers up to CurrentTier are unlocked
+func (f *Fury) refreshStats() {
 	baseStats := f.LvlStats(f.XP.LVL, f.CurrentTier)
 
 	for i := range f.CurrentTier {
@@ -60,11 +117,15 @@ func (f *Fury) GetStats() map[types.Stat]int {
 		}
 	}
 
-	return baseStats
+	f.stats = baseStats
+}
+
+func (f *Fury) GetStats() map[types.Stat]int {
+	return maps.Clone(f.stats)
 }
 
 func (f *Fury) GetStat(stat types.Stat) int {
-	if value, ok := f.GetStats()[stat]; ok {
+	if value, ok := f.stats[stat]; ok {
 		return value
 	}
 
@@ -83,20 +144,51 @@ func (f *Fury) GetSkills() []types.PlayerSkill {
 
 func (f *Fury) Serialize() map[string]any {
 	return map[string]any{
-		"name":        f.Name,
-		"master":      f.Master,
-		"tiers":       f.Tiers,
+		"uuid":        f.UUID.String(),
+		"master":      f.Master.String(),
 		"currentTier": f.CurrentTier,
-		"xp":          f.XP,
+		"xp":          []int{f.XP.LVL, f.XP.XP},
+		"kills":       f.Kills,
 	}
 }
 
-func Deserialize(data map[string]any) *Fury {
-	return &Fury{
-		Name:        data["name"].(string),
-		Master:      data["master"].(*uuid.UUID),
-		Tiers:       data["tiers"].([]FuryTier),
-		CurrentTier: data["currentTier"].(int),
-		XP:          data["xp"].(FuryXP),
+// Template data isn't persisted, returns nil for furies that no longer exist
+func Deserialize(rawData map[string]any) *Fury {
+	fury, err := New(uuid.MustParse(rawData["uuid"].(string)), uuid.MustParse(rawData["master"].(string)))
+
+	if err != nil {
+		return nil
 	}
+
+	fury.CurrentTier = min(int(rawData["currentTier"].(float64)), len(fury.Tiers))
+	fury.XP = FuryXP{
+		LVL: int(rawData["xp"].([]any)[0].(float64)),
+		XP:  int(rawData["xp"].([]any)[1].(float64)),
+	}
+
+	if rawKills, ok := rawData["kills"].(map[string]any); ok {
+		for mobId, count := range rawKills {
+			fury.Kills[mobId] = int(count.(float64))
+		}
+	}
+
+	fury.refreshStats()
+
+	return fury
+}
+
+// Template data is static, so it has to be refreshed after reloading furies
+func (f *Fury) Reload() {
+	template, exists := data.Furies[f.UUID]
+
+	if !exists {
+		return
+	}
+
+	f.Name = template.Name
+	f.Tiers = template.Tiers
+	f.LvlStats = template.LvlStats
+	f.CurrentTier = min(f.CurrentTier, len(f.Tiers))
+
+	f.refreshStats()
 }
@@ -0,0 +1,182 @@
+package world
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sao/data"
+	"sao/player"
+	"sao/types"
+	"sao/world/party"
+	"slices"
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+type FallenCharacter struct {
+	Name   string
+	UserID string
+	Level  int
+	Stats  map[types.Stat]int
+	Cause  string
+	Date   time.Time
+	//Serialized player, used when an admin restores the character
+	Data map[string]any
+}
+
+func (f *FallenCharacter) Serialize() map[string]any {
+	stats := make(map[string]int)
+
+	for stat, value := range f.Stats {
+		stats[fmt.Sprint(int(stat))] = value
+	}
+
+	return map[string]any{
+		"name":  f.Name,
+		"uid":   f.UserID,
+		"level": f.Level,
+		"stats": stats,
+		"cause": f.Cause,
+		"date":  f.Date.Unix(),
+		"data":  f.Data,
+	}
+}
+
+func DeserializeFallenCharacter(rawData map[string]any) *FallenCharacter {
+	return &FallenCharacter{
+		Name:   rawData["name"].(string),
+		UserID: rawData["uid"].(string),
+		Level:  int(rawData["level"].(float64)),
+		Stats:  player.DeserializeLevelStats(rawData["stats"].(map[string]any)),
+		Cause:  rawData["cause"].(string),
+		Date:   time.Unix(int64(rawData["date"].(float64)), 0),
+		Data:   rawData["data"].(map[string]any),
+	}
+}
+
+func (w *World) KillPlayer(p *player.Player, cause string) {
+	if _, exists := w.Players[p.GetUUID()]; !exists {
+		return
+	}
+
+	w.RemoveFromParty(p)
+	w.RemoveFromGuild(p)
+
+	for duelUuid, duel := range w.Duels {
+		if !duel.Accepted && (duel.Challenger == p.GetUUID() || duel.Target == p.GetUUID()) {
+			delete(w.Duels, duelUuid)
+		}
+	}
+
+	stats := make(map[types.Stat]int)
+
+	for stat := range types.StatToString {
+		stats[stat] = p.GetStat(stat)
+	}
+
+	p.Meta.FightInstance = nil
+	p.Meta.Travel = nil
+
+	//Round trip so the archived data looks the same as freshly loaded backup
+	rawPlayer, _ := json.Marshal(p.Serialize())
+	playerData := make(map[string]any)
+	json.Unmarshal(rawPlayer, &playerData)
+
+	w.Graveyard = append(w.Graveyard, &FallenCharacter{
+		Name:   p.GetName(),
+		UserID: p.Meta.UserID,
+		Level:  p.XP.Level,
+		Stats:  stats,
+		Cause:  cause,
+		Date:   time.Now(),
+		Data:   playerData,
+	})
+
+	delete(w.Players, p.GetUUID())
+
+	w.SendMessage(
+		data.Config.LogChannelID,
+		discord.MessageCreate{
+			Embeds: []discord.Embed{{
+				Title: "Nekrolog",
+				Description: fmt.Sprintf(
+					"%s (<@%s>) poległ na poziomie %d.\n%s\nNiech spoczywa w pokoju.",
+					p.GetName(), p.Meta.UserID, p.XP.Level, cause,
+				),
+			}},
+		},
+		false,
+	)
+}
+
+func (w *World) RemoveFromParty(p *player.Player) {
+	if p.Meta.Party == nil {
+		return
+	}
+
+	partyUuid := p.Meta.Party.UUID
+	p.Meta.Party = nil
+
+	partyObj, exists := w.Parties[partyUuid]
+
+	if !exists {
+		return
+	}
+
+	partyObj.Players = slices.DeleteFunc(partyObj.Players, func(member *party.PartyEntry) bool {
+		return member.PlayerUuid == p.GetUUID()
+	})
+
+	if len(partyObj.Players) <= 1 {
+		for _, member := range partyObj.Players {
+			if pl, ok := w.Players[member.PlayerUuid]; ok {
+				pl.Meta.Party = nil
+			}
+		}
+
+		delete(w.Parties, partyUuid)
+
+		return
+	}
+
+	if partyObj.Leader == p.GetUUID() {
+		partyObj.Leader = partyObj.Players[0].PlayerUuid
+	}
+
+	w.SyncParty(partyUuid)
+}
+
+func (w *World) RestoreFallen(idx int) (*player.Player, error) {
+	if idx < 0 || idx >= len(w.Graveyard) {
+		return nil, errors.New("FALLEN_NOT_FOUND")
+	}
+
+	fallen := w.Graveyard[idx]
+
+	if w.GetPlayer(fallen.UserID) != nil {
+		return nil, errors.New("USER_HAS_CHARACTER")
+	}
+
+	p := player.Deserialize(fallen.Data)
+
+	p.Meta.Party = nil
+	p.Stats.HP = p.GetStat(types.STAT_HP)
+	p.Stats.CurrentMana = p.GetStat(types.STAT_MANA)
+
+	w.Players[p.GetUUID()] = p
+	w.Graveyard = slices.Delete(w.Graveyard, idx, idx+1)
+
+	w.SendMessage(
+		data.Config.LogChannelID,
+		discord.MessageCreate{
+			Embeds: []discord.Embed{{
+				Title:       "Powrót z zaświatów",
+				Description: fmt.Sprintf("%s (<@%s>) zostaje przywrócony do świata żywych", p.GetName(), p.Meta.UserID),
+			}},
+		},
+		false,
+	)
+
+	return p, nil
+}
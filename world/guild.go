@@ -0,0 +1,447 @@
+package world
+
+import (
+	"errors"
+	"fmt"
+	"sao/data"
+	"sao/player"
+	"sao/types"
+	"sao/world/guild"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/google/uuid"
+)
+
+const GuildNameMaxLength = 32
+
+// Copies rank and level bonuses into every member, has to be called after each guild change
+func (w *World) SyncGuild(guildUuid uuid.UUID) {
+	guildObj, exists := w.Guilds[guildUuid]
+
+	if !exists {
+		return
+	}
+
+	bonuses := guildObj.GetBonuses()
+
+	for _, member := range guildObj.Members {
+		if pl, ok := w.Players[member.PlayerUuid]; ok {
+			pl.Meta.Guild = &player.PartialGuild{UUID: guildUuid, Rank: member.Rank, Bonuses: bonuses}
+		}
+	}
+}
+
+func (w *World) GetPlayerGuild(p *player.Player) (uuid.UUID, *guild.Guild, error) {
+	if p.Meta.Guild == nil {
+		return uuid.Nil, nil, errors.New("NOT_IN_GUILD")
+	}
+
+	guildObj, exists := w.Guilds[p.Meta.Guild.UUID]
+
+	if !exists {
+		p.Meta.Guild = nil
+
+		return uuid.Nil, nil, errors.New("NOT_IN_GUILD")
+	}
+
+	return p.Meta.Guild.UUID, guildObj, nil
+}
+
+func (w *World) CreateGuild(p *player.Player, name string) (uuid.UUID, error) {
+	name = strings.TrimSpace(name)
+
+	if p.Meta.Guild != nil {
+		return uuid.Nil, errors.New("ALREADY_IN_GUILD")
+	}
+
+	if name == "" || len([]rune(name)) > GuildNameMaxLength {
+		return uuid.Nil, errors.New("INVALID_NAME")
+	}
+
+	for _, guildObj := range w.Guilds {
+		if strings.EqualFold(guildObj.Name, name) {
+			return uuid.Nil, errors.New("NAME_TAKEN")
+		}
+	}
+
+	if p.Inventory.Gold < data.GuildConfig.CreateCost {
+		return uuid.Nil, errors.New("NOT_ENOUGH_GOLD")
+	}
+
+	p.Inventory.Gold -= data.GuildConfig.CreateCost
+
+	guildUuid := uuid.New()
+
+	w.Guilds[guildUuid] = guild.New(name, p.GetUUID())
+
+	w.SyncGuild(guildUuid)
+
+	return guildUuid, nil
+}
+
+func (w *World) InviteToGuild(inviter *player.Player, invitee *player.Player) (uuid.UUID, error) {
+	guildUuid, guildObj, err := w.GetPlayerGuild(inviter)
+
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	if !guildObj.HasPermission(inviter.GetUUID(), guild.PermInvite) {
+		return uuid.Nil, errors.New("NO_PERMISSION")
+	}
+
+	if invitee.Meta.Guild != nil {
+		return uuid.Nil, errors.New("ALREADY_IN_GUILD")
+	}
+
+	if len(guildObj.Members) >= guildObj.MaxMembers() {
+		return uuid.Nil, errors.New("GUILD_FULL")
+	}
+
+	guildObj.Invites[invitee.GetUUID()] = time.Now().Add(time.Duration(data.GuildConfig.InviteTime) * time.Minute)
+
+	return guildUuid, nil
+}
+
+func (w *World) AcceptGuildInvite(guildUuid uuid.UUID, p *player.Player) error {
+	guildObj, exists := w.Guilds[guildUuid]
+
+	if !exists {
+		return errors.New("GUILD_NOT_FOUND")
+	}
+
+	expires, invited := guildObj.Invites[p.GetUUID()]
+
+	if !invited {
+		return errors.New("NOT_INVITED")
+	}
+
+	if time.Now().After(expires) {
+		delete(guildObj.Invites, p.GetUUID())
+
+		return errors.New("INVITE_EXPIRED")
+	}
+
+	if p.Meta.Guild != nil {
+		return errors.New("ALREADY_IN_GUILD")
+	}
+
+	if len(guildObj.Members) >= guildObj.MaxMembers() {
+		return errors.New("GUILD_FULL")
+	}
+
+	delete(guildObj.Invites, p.GetUUID())
+
+	guildObj.Members = append(guildObj.Members, &guild.GuildMember{PlayerUuid: p.GetUUID(), Rank: guild.RankRecruit})
+
+	w.SyncGuild(guildUuid)
+
+	return nil
+}
+
+func (w *World) RejectGuildInvite(guildUuid uuid.UUID, p *player.Player) error {
+	guildObj, exists := w.Guilds[guildUuid]
+
+	if !exists {
+		return errors.New("GUILD_NOT_FOUND")
+	}
+
+	if _, invited := guildObj.Invites[p.GetUUID()]; !invited {
+		return errors.New("NOT_INVITED")
+	}
+
+	delete(guildObj.Invites, p.GetUUID())
+
+	return nil
+}
+
+// Both players have to be in the same guild and actor has to outrank target
+func (w *World) checkGuildAuthority(actor *player.Player, target *player.Player, permission guild.GuildPermission) (uuid.UUID, *guild.Guild, error) {
+	guildUuid, guildObj, err := w.GetPlayerGuild(actor)
+
+	if err != nil {
+		return uuid.Nil, nil, err
+	}
+
+	if actor.GetUUID() == target.GetUUID() {
+		return uuid.Nil, nil, errors.New("SELF_TARGET")
+	}
+
+	if target.Meta.Guild == nil || target.Meta.Guild.UUID != guildUuid {
+		return uuid.Nil, nil, errors.New("NOT_SAME_GUILD")
+	}
+
+	if !guildObj.HasPermission(actor.GetUUID(), permission) {
+		return uuid.Nil, nil, errors.New("NO_PERMISSION")
+	}
+
+	if guildObj.GetMember(target.GetUUID()).Rank >= guildObj.GetMember(actor.GetUUID()).Rank {
+		return uuid.Nil, nil, errors.New("NO_PERMISSION")
+	}
+
+	return guildUuid, guildObj, nil
+}
+
+// Leader promoting an officer hands over leadership and becomes an officer
+func (w *World) PromoteGuildMember(actor *player.Player, target *player.Player) (guild.GuildRank, error) {
+	guildUuid, guildObj, err := w.checkGuildAuthority(actor, target, guild.PermPromote)
+
+	if err != nil {
+		return 0, err
+	}
+
+	actorMember := guildObj.GetMember(actor.GetUUID())
+	targetMember := guildObj.GetMember(target.GetUUID())
+
+	if targetMember.Rank+1 == actorMember.Rank && actorMember.Rank != guild.RankLeader {
+		return 0, errors.New("NO_PERMISSION")
+	}
+
+	targetMember.Rank++
+
+	if targetMember.Rank == guild.RankLeader {
+		actorMember.Rank = guild.RankOfficer
+	}
+
+	w.SyncGuild(guildUuid)
+
+	return targetMember.Rank, nil
+}
+
+func (w *World) DemoteGuildMember(actor *player.Player, target *player.Player) (guild.GuildRank, error) {
+	guildUuid, guildObj, err := w.checkGuildAuthority(actor, target, guild.PermPromote)
+
+	if err != nil {
+		return 0, err
+	}
+
+	targetMember := guildObj.GetMember(target.GetUUID())
+
+	if targetMember.Rank == guild.RankRecruit {
+		return 0, errors.New("MIN_RANK")
+	}
+
+	targetMember.Rank--
+
+	w.SyncGuild(guildUuid)
+
+	return targetMember.Rank, nil
+}
+
+func (w *World) KickFromGuild(actor *player.Player, target *player.Player) error {
+	_, _, err := w.checkGuildAuthority(actor, target, guild.PermKick)
+
+	if err != nil {
+		return err
+	}
+
+	w.RemoveFromGuild(target)
+
+	return nil
+}
+
+// Leader can only leave as the last member, guild is disbanded together with its bank then
+func (w *World) LeaveGuild(p *player.Player) error {
+	_, guildObj, err := w.GetPlayerGuild(p)
+
+	if err != nil {
+		return err
+	}
+
+	if guildObj.GetMember(p.GetUUID()).Rank == guild.RankLeader && len(guildObj.Members) > 1 {
+		return errors.New("LEADER_CANNOT_LEAVE")
+	}
+
+	w.RemoveFromGuild(p)
+
+	return nil
+}
+
+// Used for leaving, kicks and dead characters, leadership goes to the highest ranked member
+func (w *World) RemoveFromGuild(p *player.Player) {
+	if p.Meta.Guild == nil {
+		return
+	}
+
+	guildUuid := p.Meta.Guild.UUID
+	p.Meta.Guild = nil
+
+	guildObj, exists := w.Guilds[guildUuid]
+
+	if !exists {
+		return
+	}
+
+	guildObj.Members = slices.DeleteFunc(guildObj.Members, func(member *guild.GuildMember) bool {
+		return member.PlayerUuid == p.GetUUID()
+	})
+
+	if len(guildObj.Members) == 0 {
+		delete(w.Guilds, guildUuid)
+
+		return
+	}
+
+	if guildObj.GetLeader() == nil {
+		successor := guildObj.Members[0]
+
+		for _, member := range guildObj.Members {
+			if member.Rank > successor.Rank {
+				successor = member
+			}
+		}
+
+		successor.Rank = guild.RankLeader
+	}
+
+	w.SyncGuild(guildUuid)
+}
+
+func (w *World) DepositGuildGold(p *player.Player, amount int) error {
+	_, guildObj, err := w.GetPlayerGuild(p)
+
+	if err != nil {
+		return err
+	}
+
+	if amount <= 0 {
+		return errors.New("INVALID_AMOUNT")
+	}
+
+	if p.Inventory.Gold < amount {
+		return errors.New("NOT_ENOUGH_GOLD")
+	}
+
+	p.Inventory.Gold -= amount
+	guildObj.Bank.Gold += amount
+
+	return nil
+}
+
+func (w *World) WithdrawGuildGold(p *player.Player, amount int) error {
+	_, guildObj, err := w.GetPlayerGuild(p)
+
+	if err != nil {
+		return err
+	}
+
+	if !guildObj.HasPermission(p.GetUUID(), guild.PermWithdraw) {
+		return errors.New("NO_PERMISSION")
+	}
+
+	if amount <= 0 {
+		return errors.New("INVALID_AMOUNT")
+	}
+
+	if guildObj.Bank.Gold < amount {
+		return errors.New("NOT_ENOUGH_GOLD")
+	}
+
+	guildObj.Bank.Gold -= amount
+	p.AddGold(amount)
+
+	return nil
+}
+
+func (w *World) DepositGuildItem(p *player.Player, itemUuid uuid.UUID, count int) error {
+	_, guildObj, err := w.GetPlayerGuild(p)
+
+	if err != nil {
+		return err
+	}
+
+	if count <= 0 {
+		return errors.New("INVALID_AMOUNT")
+	}
+
+	item, exists := data.Items[itemUuid]
+
+	if !exists {
+		return errors.New("ITEM_NOT_FOUND")
+	}
+
+	if !item.Stacks {
+		return errors.New("ITEM_NOT_STACKABLE")
+	}
+
+	if err := p.Inventory.RemoveItems([]types.WithCount[uuid.UUID]{{Item: itemUuid, Count: count}}); err != nil {
+		return err
+	}
+
+	guildObj.Bank.Items[itemUuid] += count
+
+	return nil
+}
+
+func (w *World) WithdrawGuildItem(p *player.Player, itemUuid uuid.UUID, count int) error {
+	_, guildObj, err := w.GetPlayerGuild(p)
+
+	if err != nil {
+		return err
+	}
+
+	if !guildObj.HasPermission(p.GetUUID(), guild.PermWithdraw) {
+		return errors.New("NO_PERMISSION")
+	}
+
+	if count <= 0 {
+		return errors.New("INVALID_AMOUNT")
+	}
+
+	if guildObj.Bank.Items[itemUuid] < count {
+		return errors.New("NOT_ENOUGH_ITEMS")
+	}
+
+	if _, err := p.GiveItem(itemUuid, count); err != nil {
+		return err
+	}
+
+	guildObj.Bank.Items[itemUuid] -= count
+
+	if guildObj.Bank.Items[itemUuid] == 0 {
+		delete(guildObj.Bank.Items, itemUuid)
+	}
+
+	return nil
+}
+
+// Part of exp gathered by a member goes to their guild
+func (w *World) AddGuildActivity(p *player.Player, xp int) {
+	guildUuid, guildObj, err := w.GetPlayerGuild(p)
+
+	if err != nil {
+		return
+	}
+
+	guildXp := xp * data.GuildConfig.ActivityXP / 100
+
+	if guildXp <= 0 || !guildObj.AddXP(guildXp) {
+		return
+	}
+
+	w.SyncGuild(guildUuid)
+
+	w.SendMessage(
+		data.Config.LogChannelID,
+		discord.MessageCreate{
+			Embeds: []discord.Embed{{
+				Title:       "Gildia awansuje!",
+				Description: fmt.Sprintf("Gildia %s osiągnęła poziom %d.", guildObj.Name, guildObj.GetLevel()),
+			}},
+		},
+		false,
+	)
+}
+
+func (w *World) TickGuildInvites() {
+	for _, guildObj := range w.Guilds {
+		for playerUuid, expires := range guildObj.Invites {
+			if time.Now().After(expires) {
+				delete(guildObj.Invites, playerUuid)
+			}
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package guild
+
+import (
+	"sao/data"
+	"sao/types"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Guild struct {
+	Name    string
+	Members []*GuildMember
+	Bank    GuildBank
+	//Total exp, level is derived from it using guild config
+	XP int
+	//Invited player with invite expiry
+	Invites map[uuid.UUID]time.Time
+}
+
+type GuildMember struct {
+	PlayerUuid uuid.UUID
+	Rank       GuildRank
+}
+
+// Only stacking items are kept, rolled items would lose their affixes
+type GuildBank struct {
+	Gold  int
+	Items map[uuid.UUID]int
+}
+
+type GuildRank int
+
+const (
+	RankRecruit GuildRank = iota
+	RankMember
+	RankOfficer
+	RankLeader
+)
+
+var RankToString = map[GuildRank]string{
+	RankRecruit: "Rekrut",
+	RankMember:  "Członek",
+	RankOfficer: "Oficer",
+	RankLeader:  "Lider",
+}
+
+type GuildPermission int
+
+const (
+	PermInvite GuildPermission = 1 << iota
+	PermKick
+	PermPromote
+	PermWithdraw
+)
+
+var RankPermissions = map[GuildRank]GuildPermission{
+	RankRecruit: 0,
+	RankMember:  PermInvite,
+	RankOfficer: PermInvite | PermKick | PermPromote | PermWithdraw,
+	RankLeader:  PermInvite | PermKick | PermPromote | PermWithdraw,
+}
+
+func New(name string, leader uuid.UUID) *Guild {
+	return &Guild{
+		Name:    name,
+		Members: []*GuildMember{{PlayerUuid: leader, Rank: RankLeader}},
+		Bank:    GuildBank{Items: make(map[uuid.UUID]int)},
+		Invites: make(map[uuid.UUID]time.Time),
+	}
+}
+
+func (g *Guild) GetMember(playerUuid uuid.UUID) *GuildMember {
+	for _, member := range g.Members {
+		if member.PlayerUuid == playerUuid {
+			return member
+		}
+	}
+
+	return nil
+}
+
+func (g *Guild) GetLeader() *GuildMember {
+	for _, member := range g.Members {
+		if member.Rank == RankLeader {
+			return member
+		}
+	}
+
+	return nil
+}
+
+func (g *Guild) HasPermission(playerUuid uuid.UUID, permission GuildPermission) bool {
+	member := g.GetMember(playerUuid)
+
+	if member == nil {
+		return false
+	}
+
+	return RankPermissions[member.Rank]&permission != 0
+}
+
+// Starts at 1, levels past the ones in config are never reached
+func (g *Guild) GetLevel() int {
+	level := 0
+
+	for _, levelData := range data.GuildConfig.Levels {
+		if g.XP < levelData.XP {
+			break
+		}
+
+		level++
+	}
+
+	return max(level, 1)
+}
+
+// Returns true when guild reached a new level
+func (g *Guild) AddXP(xp int) bool {
+	before := g.GetLevel()
+
+	g.XP += xp
+
+	return g.GetLevel() > before
+}
+
+// Returns -1 when guild is at max level
+func (g *Guild) NextLevelXP() int {
+	level := g.GetLevel()
+
+	if level >= len(data.GuildConfig.Levels) {
+		return -1
+	}
+
+	return data.GuildConfig.Levels[level].XP
+}
+
+func (g *Guild) MaxMembers() int {
+	return data.GuildConfig.Levels[g.GetLevel()-1].MaxMembers
+}
+
+func (g *Guild) GetBonuses() []types.GuildBonus {
+	bonuses := make([]types.GuildBonus, 0)
+
+	for _, levelData := range data.GuildConfig.Levels[:g.GetLevel()] {
+		bonuses = append(bonuses, levelData.Bonuses...)
+	}
+
+	return bonuses
+}
+
+func (g *Guild) Serialize() map[string]any {
+	members := make([]map[string]any, 0)
+
+	for _, member := range g.Members {
+		members = append(members, map[string]any{"player": member.PlayerUuid.String(), "rank": member.Rank})
+	}
+
+	items := make(map[string]int)
+
+	for itemUuid, count := range g.Bank.Items {
+		items[itemUuid.String()] = count
+	}
+
+	invites := make(map[string]int64)
+
+	for playerUuid, expires := range g.Invites {
+		invites[playerUuid.String()] = expires.Unix()
+	}
+
+	return map[string]any{
+		"name":    g.Name,
+		"members": members,
+		"gold":    g.Bank.Gold,
+		"items":   items,
+		"xp":      g.XP,
+		"invites": invites,
+	}
+}
+
+func Deserialize(rawData map[string]any) *Guild {
+	guild := &Guild{
+		Name:    rawData["name"].(string),
+		Members: make([]*GuildMember, 0),
+		Bank:    GuildBank{Gold: int(rawData["gold"].(float64)), Items: make(map[uuid.UUID]int)},
+		XP:      int(rawData["xp"].(float64)),
+		Invites: make(map[uuid.UUID]time.Time),
+	}
+
+	for _, rawMember := range rawData["members"].([]any) {
+		member := rawMember.(map[string]any)
+
+		guild.Members = append(guild.Members, &GuildMember{
+			PlayerUuid: uuid.MustParse(member["player"].(string)),
+			Rank:       GuildRank(member["rank"].(float64)),
+		})
+	}
+
+	if rawItems, ok := rawData["items"].(map[string]any); ok {
+		for itemUuid, count := range rawItems {
+			guild.Bank.Items[uuid.MustParse(itemUuid)] = int(count.(float64))
+		}
+	}
+
+	if rawInvites, ok := rawData["invites"].(map[string]any); ok {
+		for playerUuid, expires := range rawInvites {
+			guild.Invites[uuid.MustParse(playerUuid)] = time.Unix(int64(expires.(float64)), 0)
+		}
+	}
+
+	return guild
+}
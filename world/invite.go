@@ -0,0 +1,176 @@
+package world
+
+import (
+	"errors"
+	"sao/data"
+	"sao/player"
+	"sao/world/party"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Invite addressed to a single player, party is uuid.Nil when inviter wasn't in one yet
+type PartyInvite struct {
+	Party   uuid.UUID
+	Inviter uuid.UUID
+	Invitee uuid.UUID
+	Expires time.Time
+}
+
+func (pi *PartyInvite) Serialize() map[string]any {
+	return map[string]any{
+		"party":   pi.Party.String(),
+		"inviter": pi.Inviter.String(),
+		"invitee": pi.Invitee.String(),
+		"expires": pi.Expires.Unix(),
+	}
+}
+
+func DeserializePartyInvite(rawData map[string]any) *PartyInvite {
+	return &PartyInvite{
+		Party:   uuid.MustParse(rawData["party"].(string)),
+		Inviter: uuid.MustParse(rawData["inviter"].(string)),
+		Invitee: uuid.MustParse(rawData["invitee"].(string)),
+		Expires: time.Unix(int64(rawData["expires"].(float64)), 0),
+	}
+}
+
+func (w *World) InviteToParty(inviter *player.Player, invitee *player.Player) (uuid.UUID, error) {
+	if inviter.GetUUID() == invitee.GetUUID() {
+		return uuid.Nil, errors.New("SELF_INVITE")
+	}
+
+	partyUuid := uuid.Nil
+
+	if inviter.Meta.Party != nil {
+		partyUuid = inviter.Meta.Party.UUID
+		partyObj := w.Parties[partyUuid]
+
+		if partyObj.Leader != inviter.GetUUID() {
+			return uuid.Nil, errors.New("NOT_LEADER")
+		}
+
+		if len(partyObj.Players) >= data.PartyConfig.MaxSize {
+			return uuid.Nil, errors.New("PARTY_FULL")
+		}
+	}
+
+	if invitee.Meta.Party != nil {
+		return uuid.Nil, errors.New("ALREADY_IN_PARTY")
+	}
+
+	for _, invite := range w.PartyInvites {
+		if invite.Inviter == inviter.GetUUID() && invite.Invitee == invitee.GetUUID() {
+			return uuid.Nil, errors.New("ALREADY_INVITED")
+		}
+	}
+
+	inviteUuid := uuid.New()
+
+	w.PartyInvites[inviteUuid] = &PartyInvite{
+		Party:   partyUuid,
+		Inviter: inviter.GetUUID(),
+		Invitee: invitee.GetUUID(),
+		Expires: time.Now().Add(time.Duration(data.PartyConfig.InviteTime) * time.Minute),
+	}
+
+	return inviteUuid, nil
+}
+
+// Party is created on first accepted invite, so expired invites don't leave empty parties behind
+func (w *World) AcceptPartyInvite(inviteUuid uuid.UUID, p *player.Player) error {
+	invite, exists := w.PartyInvites[inviteUuid]
+
+	if !exists {
+		return errors.New("INVITE_NOT_FOUND")
+	}
+
+	if invite.Invitee != p.GetUUID() {
+		return errors.New("NOT_INVITED")
+	}
+
+	if time.Now().After(invite.Expires) {
+		delete(w.PartyInvites, inviteUuid)
+
+		return errors.New("INVITE_EXPIRED")
+	}
+
+	if p.Meta.Party != nil {
+		return errors.New("ALREADY_IN_PARTY")
+	}
+
+	inviter, exists := w.Players[invite.Inviter]
+
+	if !exists {
+		delete(w.PartyInvites, inviteUuid)
+
+		return errors.New("PARTY_NOT_FOUND")
+	}
+
+	partyUuid := invite.Party
+
+	if partyUuid == uuid.Nil {
+		if inviter.Meta.Party != nil {
+			//Earlier invite from the same player created the party
+			if w.Parties[inviter.Meta.Party.UUID].Leader != inviter.GetUUID() {
+				delete(w.PartyInvites, inviteUuid)
+
+				return errors.New("PARTY_NOT_FOUND")
+			}
+
+			partyUuid = inviter.Meta.Party.UUID
+		} else {
+			w.RegisterParty(party.Party{
+				Leader:  inviter.GetUUID(),
+				Players: []*party.PartyEntry{{PlayerUuid: inviter.GetUUID(), Role: party.None}},
+			})
+
+			partyUuid = inviter.Meta.Party.UUID
+		}
+	}
+
+	partyObj, exists := w.Parties[partyUuid]
+
+	if !exists {
+		delete(w.PartyInvites, inviteUuid)
+
+		return errors.New("PARTY_NOT_FOUND")
+	}
+
+	if len(partyObj.Players) >= data.PartyConfig.MaxSize {
+		return errors.New("PARTY_FULL")
+	}
+
+	delete(w.PartyInvites, inviteUuid)
+
+	partyObj.Players = append(partyObj.Players, &party.PartyEntry{PlayerUuid: p.GetUUID(), Role: party.None})
+
+	w.SyncParty(partyUuid)
+
+	return nil
+}
+
+func (w *World) RejectPartyInvite(inviteUuid uuid.UUID, p *player.Player) error {
+	invite, exists := w.PartyInvites[inviteUuid]
+
+	if !exists {
+		return errors.New("INVITE_NOT_FOUND")
+	}
+
+	if invite.Invitee != p.GetUUID() {
+		return errors.New("NOT_INVITED")
+	}
+
+	delete(w.PartyInvites, inviteUuid)
+
+	return nil
+}
+
+func (w *World) TickPartyInvites() {
+	for inviteUuid, invite := range w.PartyInvites {
+		if time.Now().After(invite.Expires) {
+			delete(w.PartyInvites, inviteUuid)
+		}
+	}
+}
@@ -0,0 +1,380 @@
+package world
+
+import (
+	"errors"
+	"fmt"
+	"sao/battle"
+	"sao/data"
+	"sao/player"
+	"sao/types"
+	"sao/utils"
+	"sao/world/party"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/google/uuid"
+)
+
+type LootChoice int
+
+const (
+	LootPass LootChoice = iota
+	LootGreed
+	LootNeed
+)
+
+// Need/greed roll for a single drop, not persisted as it only lasts LootRollTime
+type LootRoll struct {
+	Item      uuid.UUID
+	Count     int
+	Eligible  []uuid.UUID
+	Choices   map[uuid.UUID]LootChoice
+	ChannelId string
+}
+
+const LootRollTime = 60 * time.Second
+
+// Largest remainder split, equal weights are used when none of them is positive
+func SplitShares(total int, weights []int) []int {
+	shares := make([]int, len(weights))
+
+	if len(weights) == 0 || total <= 0 {
+		return shares
+	}
+
+	weightSum := 0
+
+	for _, weight := range weights {
+		weightSum += max(weight, 0)
+	}
+
+	if weightSum == 0 {
+		weights = make([]int, len(shares))
+
+		for idx := range weights {
+			weights[idx] = 1
+		}
+
+		weightSum = len(weights)
+	}
+
+	remainders := make([]int, len(weights))
+	given := 0
+
+	for idx, weight := range weights {
+		weight = max(weight, 0)
+
+		shares[idx] = total * weight / weightSum
+		remainders[idx] = total * weight % weightSum
+		given += shares[idx]
+	}
+
+	order := make([]int, len(weights))
+
+	for idx := range order {
+		order[idx] = idx
+	}
+
+	sort.SliceStable(order, func(i, j int) bool { return remainders[order[i]] > remainders[order[j]] })
+
+	for i := 0; given < total; i++ {
+		shares[order[i%len(order)]]++
+		given++
+	}
+
+	return shares
+}
+
+// Receivers are winners that stayed in the fight and are still alive, partyObj is nil for solo fights
+func (w *World) DistributeLoot(fight *battle.Fight, receivers []*player.Player, partyObj *party.Party, xp, gold int, items []types.Loot, xpMap, goldMap map[uuid.UUID]int, itemMap map[uuid.UUID][]string) {
+	if len(receivers) == 0 {
+		return
+	}
+
+	mode := party.LootEven
+
+	if partyObj != nil {
+		mode = partyObj.LootMode
+	}
+
+	//Shuffled so the remainder of even splits doesn't always go to the same players
+	receivers = slices.Clone(receivers)
+
+	for i := len(receivers) - 1; i > 0; i-- {
+		j := utils.RandomNumber(0, i)
+		receivers[i], receivers[j] = receivers[j], receivers[i]
+	}
+
+	weights := make([]int, len(receivers))
+
+	for idx, receiver := range receivers {
+		weights[idx] = 1
+
+		if mode == party.LootDamage {
+			weights[idx] = fight.DamageDealt[receiver.GetUUID()]
+		}
+	}
+
+	xpShares := SplitShares(xp, weights)
+	goldShares := SplitShares(gold, weights)
+
+	for idx, receiver := range receivers {
+		receiver.AddEXP(xpShares[idx])
+		receiver.AddGold(goldShares[idx])
+
+		xpMap[receiver.GetUUID()] += xpShares[idx]
+		goldMap[receiver.GetUUID()] += goldShares[idx]
+	}
+
+	for _, loot := range items {
+		itemUuid, err := uuid.Parse(loot.Item)
+
+		if err != nil {
+			continue
+		}
+
+		switch mode {
+		case party.LootEven:
+			w.GiveLootItem(utils.RandomElement(receivers), itemUuid, loot.Count, itemMap)
+		case party.LootDamage:
+			w.GiveLootItem(WeightedReceiver(receivers, weights), itemUuid, loot.Count, itemMap)
+		case party.LootRoundRobin:
+			w.GiveLootItem(NextRoundRobinReceiver(partyObj, receivers), itemUuid, loot.Count, itemMap)
+		case party.LootNeedGreed:
+			w.StartLootRoll(fight.GetChannelId(), receivers, itemUuid, loot.Count)
+		}
+	}
+}
+
+func (w *World) GiveLootItem(receiver *player.Player, itemUuid uuid.UUID, count int, itemMap map[uuid.UUID][]string) {
+	given, err := receiver.GiveItem(itemUuid, count)
+
+	if err != nil {
+		return
+	}
+
+	for _, item := range given {
+		itemMap[receiver.GetUUID()] = append(
+			itemMap[receiver.GetUUID()], fmt.Sprintf("%dx %s", item.Count, item.DisplayName()),
+		)
+	}
+}
+
+func WeightedReceiver(receivers []*player.Player, weights []int) *player.Player {
+	weightSum := 0
+
+	for _, weight := range weights {
+		weightSum += max(weight, 0)
+	}
+
+	if weightSum == 0 {
+		return utils.RandomElement(receivers)
+	}
+
+	roll := utils.RandomNumber(1, weightSum)
+
+	for idx, weight := range weights {
+		roll -= max(weight, 0)
+
+		if roll <= 0 {
+			return receivers[idx]
+		}
+	}
+
+	return receivers[len(receivers)-1]
+}
+
+// Members that can't receive loot are skipped without losing their turn
+func NextRoundRobinReceiver(partyObj *party.Party, receivers []*player.Player) *player.Player {
+	for range partyObj.Players {
+		partyObj.LootCursor = partyObj.LootCursor % len(partyObj.Players)
+		member := partyObj.Players[partyObj.LootCursor]
+
+		for _, receiver := range receivers {
+			if receiver.GetUUID() == member.PlayerUuid {
+				partyObj.LootCursor++
+
+				return receiver
+			}
+		}
+
+		partyObj.LootCursor++
+	}
+
+	return utils.RandomElement(receivers)
+}
+
+func (w *World) StartLootRoll(channelId string, receivers []*player.Player, itemUuid uuid.UUID, count int) {
+	rollUuid := uuid.New()
+	eligible := make([]uuid.UUID, 0)
+	mentions := make([]string, 0)
+
+	for _, receiver := range receivers {
+		eligible = append(eligible, receiver.GetUUID())
+		mentions = append(mentions, fmt.Sprintf("<@%s>", receiver.Meta.UserID))
+	}
+
+	w.LootRolls[rollUuid] = &LootRoll{
+		Item:      itemUuid,
+		Count:     count,
+		Eligible:  eligible,
+		Choices:   make(map[uuid.UUID]LootChoice),
+		ChannelId: channelId,
+	}
+
+	w.SendMessage(
+		channelId,
+		discord.NewMessageCreateBuilder().
+			SetContent(strings.Join(mentions, ", ")).
+			AddEmbeds(discord.NewEmbedBuilder().
+				SetTitle("Podział łupów").
+				SetDescriptionf(
+					"Do zdobycia: %dx %s\nWybór trwa %d sekund, brak wyboru oznacza pas.",
+					count, data.Items[itemUuid].Name, int(LootRollTime.Seconds()),
+				).
+				Build(),
+			).
+			AddActionRow(
+				discord.NewSuccessButton("Potrzebuję", "roll/need|"+rollUuid.String()),
+				discord.NewPrimaryButton("Chcę", "roll/greed|"+rollUuid.String()),
+				discord.NewSecondaryButton("Pasuję", "roll/pass|"+rollUuid.String()),
+			).
+			Build(),
+		false,
+	)
+
+	time.AfterFunc(LootRollTime, func() {
+		w.Mutex.Lock()
+		defer w.Mutex.Unlock()
+
+		w.ResolveLootRoll(rollUuid)
+	})
+}
+
+func (w *World) ChooseLoot(rollUuid uuid.UUID, playerUuid uuid.UUID, choice LootChoice) error {
+	roll, exists := w.LootRolls[rollUuid]
+
+	if !exists {
+		return errors.New("ROLL_NOT_FOUND")
+	}
+
+	if !slices.Contains(roll.Eligible, playerUuid) {
+		return errors.New("NOT_ELIGIBLE")
+	}
+
+	if _, chosen := roll.Choices[playerUuid]; chosen {
+		return errors.New("ALREADY_CHOSEN")
+	}
+
+	roll.Choices[playerUuid] = choice
+
+	if len(roll.Choices) == len(roll.Eligible) {
+		w.ResolveLootRoll(rollUuid)
+	}
+
+	return nil
+}
+
+// Need beats greed, ties inside a group are settled by a 1-100 roll
+func (w *World) ResolveLootRoll(rollUuid uuid.UUID) {
+	roll, exists := w.LootRolls[rollUuid]
+
+	if !exists {
+		return
+	}
+
+	delete(w.LootRolls, rollUuid)
+
+	candidates := make([]uuid.UUID, 0)
+	bestChoice := LootGreed
+
+	for _, playerUuid := range roll.Eligible {
+		choice := roll.Choices[playerUuid]
+
+		if choice == LootPass || choice < bestChoice {
+			continue
+		}
+
+		if choice > bestChoice {
+			bestChoice = choice
+			candidates = candidates[:0]
+		}
+
+		candidates = append(candidates, playerUuid)
+	}
+
+	rollsText := ""
+	var winner *player.Player
+
+	if len(candidates) == 0 {
+		alive := make([]*player.Player, 0)
+
+		for _, playerUuid := range roll.Eligible {
+			if pl, exists := w.Players[playerUuid]; exists {
+				alive = append(alive, pl)
+			}
+		}
+
+		if len(alive) == 0 {
+			return
+		}
+
+		winner = utils.RandomElement(alive)
+		rollsText = "Wszyscy spasowali, przedmiot trafia do losowego gracza\n"
+	} else {
+		bestRoll := 0
+
+		for _, playerUuid := range candidates {
+			pl, exists := w.Players[playerUuid]
+
+			if !exists {
+				continue
+			}
+
+			value := utils.RandomNumber(1, 100)
+			rollsText += fmt.Sprintf("%s: %d\n", pl.GetName(), value)
+
+			if value > bestRoll {
+				bestRoll = value
+				winner = pl
+			}
+		}
+
+		if winner == nil {
+			return
+		}
+	}
+
+	itemMap := make(map[uuid.UUID][]string)
+
+	w.GiveLootItem(winner, roll.Item, roll.Count, itemMap)
+
+	choiceText := "Chcę"
+
+	if bestChoice == LootNeed {
+		choiceText = "Potrzebuję"
+	}
+
+	if len(candidates) == 0 {
+		choiceText = "Pas"
+	}
+
+	w.SendMessage(
+		roll.ChannelId,
+		discord.MessageCreate{
+			Embeds: []discord.Embed{discord.NewEmbedBuilder().
+				SetTitle("Podział łupów").
+				SetDescriptionf(
+					"%s (%s)\n%sOtrzymuje: %s (<@%s>) - %s",
+					data.Items[roll.Item].Name, choiceText, rollsText, winner.GetName(), winner.Meta.UserID,
+					strings.Join(itemMap[winner.GetUUID()], ", "),
+				).
+				Build(),
+			},
+		},
+		false,
+	)
+}
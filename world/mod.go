@@ -11,11 +11,13 @@ import (
 	"sao/player"
 	"sao/types"
 	"sao/utils"
+	"sao/world/guild"
 	"sao/world/party"
 	"sao/world/tournament"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/disgoorg/disgo"
@@ -32,6 +34,70 @@ type World struct {
 	Fights         map[uuid.UUID]*battle.Fight
 	Parties        map[uuid.UUID]*party.Party
 	DiscordChannel chan types.DiscordEvent
+	//Floors unlocked by defeating bosses, persisted in backups
+	UnlockedFloors []string
+	//Minutes until next spawn of each world boss
+	BossTimers   map[uuid.UUID]int
+	ActiveBosses map[uuid.UUID]*ActiveWorldBoss
+	Duels        map[uuid.UUID]*Duel
+	LootRolls    map[uuid.UUID]*LootRoll
+	//Keyed by party
+	ReadyChecks map[uuid.UUID]*ReadyCheck
+	//Characters lost in hardcore mode
+	Graveyard    []*FallenCharacter
+	PartyInvites map[uuid.UUID]*PartyInvite
+	Guilds       map[uuid.UUID]*guild.Guild
+	//Finished tournaments, oldest first
+	TournamentHistory []*tournament.HistoryEntry
+	//Spectator bets on tournament matches
+	Bets map[uuid.UUID]*TournamentBet
+	//Held by discord handlers, clock ticks and timers while they change world state
+	Mutex sync.Mutex
+}
+
+type Duel struct {
+	Challenger uuid.UUID
+	Target     uuid.UUID
+	//Gold from each side, held by the world after accepting
+	Stake    int
+	Accepted bool
+	//Challenge can't be accepted after it
+	Expires time.Time
+}
+
+type ActiveWorldBoss struct {
+	Boss         uuid.UUID
+	Participants []uuid.UUID
+	//Minutes left in the join window
+	Remaining int
+}
+
+func (ab *ActiveWorldBoss) Serialize() map[string]any {
+	participants := make([]string, 0)
+
+	for _, participant := range ab.Participants {
+		participants = append(participants, participant.String())
+	}
+
+	return map[string]any{
+		"boss":         ab.Boss.String(),
+		"participants": participants,
+		"remaining":    ab.Remaining,
+	}
+}
+
+func DeserializeActiveWorldBoss(rawData map[string]any) *ActiveWorldBoss {
+	active := &ActiveWorldBoss{
+		Boss:         uuid.MustParse(rawData["boss"].(string)),
+		Participants: make([]uuid.UUID, 0),
+		Remaining:    int(rawData["remaining"].(float64)),
+	}
+
+	for _, participant := range rawData["participants"].([]any) {
+		active.Participants = append(active.Participants, uuid.MustParse(participant.(string)))
+	}
+
+	return active
 }
 
 func CreateWorld() World {
@@ -41,6 +107,18 @@ func CreateWorld() World {
 		make(map[uuid.UUID]*battle.Fight),
 		make(map[uuid.UUID]*party.Party),
 		make(chan types.DiscordEvent, 10),
+		make([]string, 0),
+		make(map[uuid.UUID]int),
+		make(map[uuid.UUID]*ActiveWorldBoss),
+		make(map[uuid.UUID]*Duel),
+		make(map[uuid.UUID]*LootRoll),
+		make(map[uuid.UUID]*ReadyCheck),
+		make([]*FallenCharacter, 0),
+		make(map[uuid.UUID]*PartyInvite),
+		make(map[uuid.UUID]*guild.Guild),
+		make([]*tournament.HistoryEntry, 0),
+		make(map[uuid.UUID]*TournamentBet),
+		sync.Mutex{},
 	}
 }
 
@@ -57,23 +135,36 @@ func (w *World) RequestChoice(choiceId string, choose func(cic *events.Component
 }
 
 func (w *World) PlayerFight(pUuid uuid.UUID, location *types.Location, threadId string, mobId string, mobCount int) {
-	playerObj := w.Players[pUuid]
+	enemies := make([]*mobs.MobEntity, 0)
 
-	entityMap := make(battle.EntityMap)
+	for range mobCount {
+		enemies = append(enemies, mobs.Spawn(mobId))
+	}
+
+	w.PlayerEncounter(pUuid, location, threadId, enemies)
+}
+
+// Party members have to confirm they are ready before joining
+func (w *World) PlayerEncounter(pUuid uuid.UUID, location *types.Location, threadId string, enemies []*mobs.MobEntity) {
+	playerObj := w.Players[pUuid]
 
 	if playerObj.Meta.Party != nil {
-		for _, member := range w.Parties[playerObj.Meta.Party.UUID].Players {
-			mUuid := member.PlayerUuid
+		w.StartReadyCheck(pUuid, location, threadId, enemies)
 
-			entityMap[mUuid] = &battle.EntityEntry{Entity: w.Players[mUuid]}
-		}
-	} else {
-		entityMap[pUuid] = &battle.EntityEntry{Entity: playerObj}
+		return
 	}
 
-	for range mobCount {
-		entity := mobs.Spawn(mobId)
+	w.StartFight([]uuid.UUID{pUuid}, location, threadId, enemies)
+}
 
+func (w *World) StartFight(members []uuid.UUID, location *types.Location, threadId string, enemies []*mobs.MobEntity) {
+	entityMap := make(battle.EntityMap)
+
+	for _, mUuid := range members {
+		entityMap[mUuid] = &battle.EntityEntry{Entity: w.Players[mUuid]}
+	}
+
+	for _, entity := range enemies {
 		entityMap[entity.GetUUID()] = &battle.EntityEntry{Entity: entity, Side: 1}
 	}
 
@@ -90,27 +181,62 @@ func (w *World) PlayerFight(pUuid uuid.UUID, location *types.Location, threadId
 
 	mentionString := ""
 
-	for _, entity := range fight.Entities {
-		if entity.Entity.GetFlags()&types.ENTITY_AUTO == 0 {
-			mentionString += fmt.Sprintf("<@%v>, ", entity.Entity.(*player.Player).Meta.UserID)
-		}
+	for _, mUuid := range members {
+		mentionString += fmt.Sprintf("<@%v>, ", w.Players[mUuid].Meta.UserID)
+
+		w.Players[mUuid].Meta.FightInstance = &fightUUID
 	}
 
 	w.SendMessage(fight.GetChannelId(), discord.MessageCreate{Content: mentionString[:len(mentionString)-2]}, false)
 
-	playerObj.Meta.FightInstance = &fightUUID
-
 	go w.ListenForFight(fightUUID)
 }
 
 func (w *World) PlayerSearch(pUuid uuid.UUID, threadId string, event *events.ApplicationCommandInteractionCreate) {
 	player := w.Players[pUuid]
 
-	cid := event.Channel().ID()
+	location := player.GetLocation()
+
+	if player.GetCurrentHP() <= 0 {
+		event.CreateMessage(discord.
+			NewMessageCreateBuilder().
+			SetContentf("Nie żyjesz, odrodzisz się za %d min", player.Meta.Respawn).
+			SetEphemeral(true).
+			Build(),
+		)
+
+		return
+	}
+
+	if player.Meta.Party != nil && w.ReadyChecks[player.Meta.Party.UUID] != nil {
+		event.CreateMessage(discord.
+			NewMessageCreateBuilder().
+			SetContent("Twoje party sprawdza gotowość do walki").
+			SetEphemeral(true).
+			Build(),
+		)
+
+		return
+	}
+
+	//Picker only lists enemies, encounter table alone leaves it empty
+	nothingToFind := location == nil || len(location.Enemies) == 0 &&
+		(len(location.Encounters) == 0 || slices.Contains(location.Flags, "picker"))
+
+	if player.Meta.FightInstance != nil || nothingToFind {
+		event.CreateMessage(discord.
+			NewMessageCreateBuilder().
+			SetContent("Nie ma czego tu szukać...").
+			SetEphemeral(true).
+			Build(),
+		)
+
+		return
+	}
 
-	location := data.FloorMap.FindLocation(func(l types.Location) bool { return l.CID == cid.String() })
+	if !slices.Contains(location.Flags, "picker") {
+		w.PlayerRollEncounter(pUuid, location, threadId, event)
 
-	if player.Meta.FightInstance != nil || location == nil || len(location.Enemies) == 0 {
 		return
 	}
 
@@ -118,6 +244,8 @@ func (w *World) PlayerSearch(pUuid uuid.UUID, threadId string, event *events.App
 		enemy := location.Enemies[0]
 
 		if enemy.MinNum == enemy.MaxNum {
+			event.CreateMessage(discord.NewMessageCreateBuilder().SetContent("Szukanie...").SetEphemeral(true).Build())
+
 			go w.PlayerFight(pUuid, location, threadId, enemy.Enemy, enemy.MinNum)
 
 			return
@@ -220,113 +348,779 @@ func (w *World) PlayerSearch(pUuid uuid.UUID, threadId string, event *events.App
 			Build(),
 		)
 
-		w.RequestChoice(selectMenuUuid, func(cic *events.ComponentInteractionCreate) {
-			choiceRaw := cic.StringSelectMenuInteractionData().Values[0]
+		w.RequestChoice(selectMenuUuid, func(cic *events.ComponentInteractionCreate) {
+			choiceRaw := cic.StringSelectMenuInteractionData().Values[0]
+
+			choice, _ := strconv.Atoi(choiceRaw)
+
+			cic.UpdateMessage(discord.
+				NewMessageUpdateBuilder().
+				ClearContainerComponents().
+				SetContent("Wybrano " + choiceRaw + " przeciwników!").
+				Build(),
+			)
+
+			go w.PlayerFight(pUuid, location, threadId, enemy.Enemy, choice)
+		})
+	})
+}
+
+func RollEncounter(location *types.Location) types.Encounter {
+	encounters := location.Encounters
+
+	//Locations without encounter table treat every enemy as an equal encounter
+	if len(encounters) == 0 {
+		for _, enemy := range location.Enemies {
+			encounters = append(encounters, types.Encounter{Weight: 1, Mobs: []types.EnemyMeta{enemy}})
+		}
+	}
+
+	overall := 0
+
+	for _, encounter := range encounters {
+		overall += encounter.Weight
+	}
+
+	roll := utils.RandomNumber(1, overall)
+
+	for _, encounter := range encounters {
+		if roll <= encounter.Weight {
+			return encounter
+		}
+
+		roll -= encounter.Weight
+	}
+
+	return encounters[len(encounters)-1]
+}
+
+func (w *World) PlayerRollEncounter(pUuid uuid.UUID, location *types.Location, threadId string, event *events.ApplicationCommandInteractionCreate) {
+	player := w.Players[pUuid]
+
+	encounter := RollEncounter(location)
+	enemies := mobs.SpawnEncounter(encounter, player.XP.Level)
+
+	if len(enemies) == 0 {
+		event.CreateMessage(discord.NewMessageCreateBuilder().SetContent("Nic nie znaleziono...").SetEphemeral(true).Build())
+
+		return
+	}
+
+	enemiesText := ""
+
+	for _, enemy := range enemies {
+		enemiesText += fmt.Sprintf("- %s (%d HP)\n", enemy.GetName(), enemy.GetStat(types.STAT_HP))
+	}
+
+	title := "Spotkanie!"
+
+	if encounter.Rare {
+		title = "Rzadkie spotkanie!"
+	}
+
+	embed := discord.NewEmbedBuilder().SetTitle(title).SetDescription(enemiesText[:len(enemiesText)-1])
+
+	if !data.WorldConfig.AllowRetreat {
+		event.CreateMessage(discord.NewMessageCreateBuilder().AddEmbeds(embed.Build()).Build())
+
+		go w.PlayerEncounter(pUuid, location, threadId, enemies)
+
+		return
+	}
+
+	selectMenuUuid := uuid.New().String()
+
+	event.CreateMessage(discord.
+		NewMessageCreateBuilder().
+		AddEmbeds(embed.Build()).
+		AddActionRow(discord.
+			NewStringSelectMenu("chc/"+selectMenuUuid, "Co robisz?").
+			WithMaxValues(1).
+			AddOptions(
+				discord.NewStringSelectMenuOption("Walcz", "fight"),
+				discord.NewStringSelectMenuOption("Wycofaj się", "retreat"),
+			),
+		).
+		Build(),
+	)
+
+	w.RequestChoice(selectMenuUuid, func(cic *events.ComponentInteractionCreate) {
+		if cic.StringSelectMenuInteractionData().Values[0] == "retreat" {
+			cic.UpdateMessage(discord.
+				NewMessageUpdateBuilder().
+				ClearContainerComponents().
+				SetContent("Wycofano się z walki").
+				Build(),
+			)
+
+			return
+		}
+
+		if player.Meta.FightInstance != nil {
+			cic.UpdateMessage(discord.
+				NewMessageUpdateBuilder().
+				ClearContainerComponents().
+				SetContent("Już jesteś w walce").
+				Build(),
+			)
+
+			return
+		}
+
+		cic.UpdateMessage(discord.
+			NewMessageUpdateBuilder().
+			ClearContainerComponents().
+			SetContent("Do walki!").
+			Build(),
+		)
+
+		go w.PlayerEncounter(pUuid, location, threadId, enemies)
+	})
+}
+
+func (w *World) RevivePlayer(p *player.Player) {
+	p.Stats.HP = p.GetStat(types.STAT_HP)
+	p.Stats.CurrentMana = p.GetStat(types.STAT_MANA)
+
+	w.SendMessage(
+		data.Config.LogChannelID,
+		discord.MessageCreate{
+			Embeds: []discord.Embed{{
+				Title:       "Wskrzeszenie!",
+				Description: fmt.Sprintf("%s zostaje wskrzeszony...", p.GetName()),
+			}},
+		},
+		false,
+	)
+}
+
+// Deaths in duels and tournaments have no consequences
+func (w *World) HandleFightDeaths(fight *battle.Fight) {
+	if fight.Meta.Duel != nil || fight.Meta.Tournament != nil {
+		return
+	}
+
+	for _, entry := range fight.Entities {
+		if entry.Entity.GetFlags()&types.ENTITY_AUTO != 0 || entry.Entity.GetCurrentHP() > 0 {
+			continue
+		}
+
+		pl := entry.Entity.(*player.Player)
+
+		if !data.WorldConfig.Hardcore {
+			w.PenalizeDeath(pl)
+			continue
+		}
+
+		killers := make([]string, 0)
+
+		for _, other := range fight.Entities {
+			if other.Side != entry.Side && !slices.Contains(killers, other.Entity.GetName()) {
+				killers = append(killers, other.Entity.GetName())
+			}
+		}
+
+		w.KillPlayer(pl, "Pokonany przez: "+strings.Join(killers, ", "))
+	}
+}
+
+func (w *World) PenalizeDeath(p *player.Player) {
+	penalty := p.ApplyDeathPenalty()
+
+	w.SendMessage(
+		data.Config.LogChannelID,
+		discord.MessageCreate{
+			Embeds: []discord.Embed{{
+				Title: "Śmierć!",
+				Description: fmt.Sprintf(
+					"%s (<@%s>) ginie, traci %d exp i %d złota. Odrodzi się w lokacji %s za %d min",
+					p.GetName(), p.Meta.UserID, penalty.XP, penalty.Gold, penalty.Respawn.Location, p.Meta.Respawn,
+				),
+			}},
+		},
+		false,
+	)
+}
+
+func (w *World) RespecPlayer(p *player.Player, location *types.Location) (int, error) {
+	cost := p.GetRespecCost()
+
+	refunded, err := p.Respec(location)
+
+	if err != nil {
+		return 0, err
+	}
+
+	w.SendMessage(
+		data.Config.LogChannelID,
+		discord.MessageCreate{
+			Embeds: []discord.Embed{{
+				Title: "Reset umiejętności",
+				Description: fmt.Sprintf(
+					"%s (<@%s>) zresetował umiejętności na poziomie %d za %d złota, zwrócono %d punktów akcji",
+					p.GetName(), p.Meta.UserID, p.XP.Level, cost, refunded,
+				),
+			}},
+		},
+		false,
+	)
+
+	return refunded, nil
+}
+
+func (w *World) TickPlayer(p *player.Player) {
+	p.TickTimedEffects()
+
+	if p.TickTravel() {
+		if loc := p.GetLocation(); loc != nil {
+			w.SendMessage(
+				loc.CID,
+				discord.MessageCreate{Content: fmt.Sprintf("<@%s> dociera do lokacji %s", p.Meta.UserID, loc.Name)},
+				false,
+			)
+		}
+	}
+
+	if p.Meta.FightInstance != nil {
+		return
+	}
+
+	//Hardcore deaths are handled when the fight ends, only respawns are left here
+	if p.GetCurrentHP() <= 0 && !data.WorldConfig.Hardcore {
+		if p.TickRespawn() {
+			w.RevivePlayer(p)
+		}
+
+		return
+	}
+
+	if p.GetCurrentMana() < p.GetStat(types.STAT_MANA) {
+		p.Stats.CurrentMana += 1
+	}
+
+	if p.GetCurrentHP() < p.GetStat(types.STAT_HP) {
+		p.Heal(p.GetStat(types.STAT_HP) / 25)
+
+		if p.Meta.WaitToHeal && p.GetCurrentHP() >= p.GetStat(types.STAT_HP) {
+			p.Meta.WaitToHeal = false
+
+			client, err := disgo.New(data.Config.Token)
+
+			if err != nil {
+				w.SendMessage(
+					data.Config.LogChannelID,
+					discord.MessageCreate{Content: "Nie można wysłać wiadomości do gracza (HP Heal)"},
+					false,
+				)
+			}
+
+			ch, err := client.Rest().CreateDMChannel(snowflake.MustParse(p.Meta.UserID))
+
+			if err != nil {
+				w.SendMessage(
+					data.Config.LogChannelID,
+					discord.NewMessageCreateBuilder().
+						SetContent("Nie można wysłać wiadomości do gracza (HP Heal)").
+						Build(),
+					false,
+				)
+			}
+
+			_, err = client.Rest().CreateMessage(
+				ch.ID(), discord.MessageCreate{Content: "Twoja postać ma już 100% HP, baw się dobrze!"},
+			)
+
+			if err != nil {
+				w.SendMessage(
+					data.Config.LogChannelID,
+					discord.MessageCreate{Content: "Nie można wysłać wiadomości do gracza (HP Heal)"},
+					false,
+				)
+			}
+		}
+	}
+}
+
+func (w *World) StartClock() {
+	counter := 0
+
+	for range time.Tick(1 * time.Minute) {
+		w.Mutex.Lock()
+
+		for _, player := range w.Players {
+			w.TickPlayer(player)
+		}
+
+		w.TickWorldBosses()
+		w.TickPartyInvites()
+		w.TickGuildInvites()
+		w.TickDuels()
+		w.TickTournaments()
+
+		counter++
+
+		if counter >= 15 {
+			counter = 0
+
+			w.CreateBackup()
+		}
+
+		w.Mutex.Unlock()
+	}
+}
+
+func (w *World) ChallengeDuel(challenger *player.Player, target *player.Player, stake int) (uuid.UUID, error) {
+	if challenger.GetUUID() == target.GetUUID() {
+		return uuid.Nil, errors.New("SELF_DUEL")
+	}
+
+	if stake < 0 {
+		return uuid.Nil, errors.New("INVALID_STAKE")
+	}
+
+	if err := CanDuel(challenger, target, stake); err != nil {
+		return uuid.Nil, err
+	}
+
+	duelUuid := uuid.New()
+
+	w.Duels[duelUuid] = &Duel{
+		Challenger: challenger.GetUUID(),
+		Target:     target.GetUUID(),
+		Stake:      stake,
+		Expires:    time.Now().Add(time.Duration(data.WorldConfig.DuelTime) * time.Minute),
+	}
+
+	return duelUuid, nil
+}
+
+func CanDuel(challenger *player.Player, target *player.Player, stake int) error {
+	if challenger.Meta.FightInstance != nil || target.Meta.FightInstance != nil {
+		return errors.New("PLAYER_IN_FIGHT")
+	}
+
+	if challenger.Meta.Travel != nil || target.Meta.Travel != nil || challenger.Meta.Location != target.Meta.Location {
+		return errors.New("WRONG_LOCATION")
+	}
+
+	if challenger.GetCurrentHP() <= 0 || target.GetCurrentHP() <= 0 {
+		return errors.New("PLAYER_DEAD")
+	}
+
+	if challenger.Inventory.Gold < stake || target.Inventory.Gold < stake {
+		return errors.New("NOT_ENOUGH_GOLD")
+	}
+
+	return nil
+}
+
+// Accepted duels are cleaned up when their fight ends
+func (w *World) TickDuels() {
+	for duelUuid, duel := range w.Duels {
+		if !duel.Accepted && time.Now().After(duel.Expires) {
+			delete(w.Duels, duelUuid)
+		}
+	}
+}
+
+func (w *World) DeclineDuel(duelUuid uuid.UUID) error {
+	duel, exists := w.Duels[duelUuid]
+
+	if !exists || duel.Accepted {
+		return errors.New("DUEL_NOT_FOUND")
+	}
+
+	delete(w.Duels, duelUuid)
+
+	return nil
+}
+
+func (w *World) AcceptDuel(duelUuid uuid.UUID) error {
+	duel, exists := w.Duels[duelUuid]
+
+	if !exists || duel.Accepted {
+		return errors.New("DUEL_NOT_FOUND")
+	}
+
+	if time.Now().After(duel.Expires) {
+		delete(w.Duels, duelUuid)
+
+		return errors.New("DUEL_EXPIRED")
+	}
+
+	challenger := w.Players[duel.Challenger]
+	target := w.Players[duel.Target]
+
+	if err := CanDuel(challenger, target, duel.Stake); err != nil {
+		delete(w.Duels, duelUuid)
+
+		return err
+	}
+
+	duel.Accepted = true
+
+	challenger.Inventory.Gold -= duel.Stake
+	target.Inventory.Gold -= duel.Stake
+
+	location := challenger.GetLocation()
+
+	entityMap := make(battle.EntityMap)
+
+	entityMap[challenger.GetUUID()] = &battle.EntityEntry{Entity: challenger, Side: 0}
+	entityMap[target.GetUUID()] = &battle.EntityEntry{Entity: target, Side: 1}
+
+	fight := battle.Fight{
+		Entities:       entityMap,
+		DiscordChannel: w.DiscordChannel,
+		Location:       location,
+		Meta:           &battle.FightMeta{Duel: &duelUuid},
+	}
+
+	fight.Init()
+
+	fightUUID := w.RegisterFight(&fight)
+
+	challenger.Meta.FightInstance = &fightUUID
+	target.Meta.FightInstance = &fightUUID
+
+	go w.ListenForFight(fightUUID)
+
+	return nil
+}
+
+// Winner is uuid.Nil when nobody won, stakes are refunded then
+func (w *World) FinishDuel(duelUuid uuid.UUID, winner uuid.UUID) {
+	duel, exists := w.Duels[duelUuid]
+
+	if !exists {
+		return
+	}
+
+	delete(w.Duels, duelUuid)
+
+	challenger := w.Players[duel.Challenger]
+	target := w.Players[duel.Target]
+
+	//No death penalty in duels
+	for _, pl := range []*player.Player{challenger, target} {
+		if pl.Stats.HP <= 0 {
+			pl.Stats.HP = 1
+		}
+	}
+
+	if winner == uuid.Nil {
+		challenger.AddGold(duel.Stake)
+		target.AddGold(duel.Stake)
+
+		return
+	}
+
+	winnerObj, loserObj := challenger, target
+
+	if winner == duel.Target {
+		winnerObj, loserObj = target, challenger
+	}
+
+	winnerObj.AddGold(duel.Stake * 2)
+
+	winnerObj.PvP.Wins++
+	winnerObj.PvP.GoldWon += duel.Stake
+	loserObj.PvP.Losses++
+	loserObj.PvP.GoldLost += duel.Stake
+}
+
+func (w *World) TickWorldBosses() {
+	//Bosses restored from a backup could be removed from game data in the meantime
+	for bossUuid := range w.ActiveBosses {
+		if _, exists := data.WorldBosses[bossUuid]; !exists {
+			delete(w.ActiveBosses, bossUuid)
+		}
+	}
+
+	for bossUuid, boss := range data.WorldBosses {
+		if active, exists := w.ActiveBosses[bossUuid]; exists {
+			active.Remaining--
+
+			if active.Remaining <= 0 {
+				w.StartWorldBossFight(bossUuid)
+			}
+
+			continue
+		}
+
+		if _, exists := w.BossTimers[bossUuid]; !exists {
+			w.BossTimers[bossUuid] = boss.Interval
+		}
+
+		w.BossTimers[bossUuid]--
+
+		if w.BossTimers[bossUuid] <= 0 {
+			w.BossTimers[bossUuid] = boss.Interval
+
+			w.SpawnWorldBoss(bossUuid)
+		}
+	}
+}
+
+func (w *World) SpawnWorldBoss(bossUuid uuid.UUID) {
+	boss := data.WorldBosses[bossUuid]
+	location := data.FloorMap.GetLocation(types.EntityLocation{Floor: boss.Floor, Location: boss.Location})
+
+	if location == nil {
+		return
+	}
+
+	w.ActiveBosses[bossUuid] = &ActiveWorldBoss{
+		Boss:         bossUuid,
+		Participants: make([]uuid.UUID, 0),
+		Remaining:    boss.JoinWindow,
+	}
+
+	w.SendMessage(
+		location.CID,
+		discord.NewMessageCreateBuilder().
+			AddEmbeds(discord.NewEmbedBuilder().
+				SetTitle("Boss świata!").
+				SetDescriptionf(
+					"%s pojawia się w lokacji %s! Dołącz do walki w ciągu %d min.", boss.Name, boss.Location, boss.JoinWindow,
+				).
+				Build(),
+			).
+			AddActionRow(discord.NewPrimaryButton("Dołącz", "boss/join|"+bossUuid.String())).
+			Build(),
+		false,
+	)
+}
+
+// Party members in the same location join together, returns everyone that joined
+func (w *World) JoinWorldBoss(p *player.Player, bossUuid uuid.UUID) ([]*player.Player, error) {
+	active, exists := w.ActiveBosses[bossUuid]
+
+	if !exists {
+		return nil, errors.New("BOSS_NOT_ACTIVE")
+	}
+
+	boss := data.WorldBosses[bossUuid]
+	bossLocation := types.EntityLocation{Floor: boss.Floor, Location: boss.Location}
+
+	if p.Meta.Location != bossLocation || p.Meta.Travel != nil {
+		return nil, errors.New("WRONG_LOCATION")
+	}
+
+	if p.Meta.FightInstance != nil {
+		return nil, errors.New("PLAYER_IN_FIGHT")
+	}
+
+	if p.GetCurrentHP() <= 0 {
+		return nil, errors.New("PLAYER_DEAD")
+	}
+
+	if slices.Contains(active.Participants, p.GetUUID()) {
+		return nil, errors.New("ALREADY_JOINED")
+	}
+
+	candidates := []*player.Player{p}
+
+	if p.Meta.Party != nil {
+		for _, member := range w.Parties[p.Meta.Party.UUID].Players {
+			if member.PlayerUuid != p.GetUUID() {
+				candidates = append(candidates, w.Players[member.PlayerUuid])
+			}
+		}
+	}
+
+	joined := make([]*player.Player, 0)
+
+	for _, candidate := range candidates {
+		if slices.Contains(active.Participants, candidate.GetUUID()) {
+			continue
+		}
+
+		if candidate.Meta.Location != bossLocation || candidate.Meta.Travel != nil {
+			continue
+		}
+
+		if candidate.Meta.FightInstance != nil || candidate.GetCurrentHP() <= 0 {
+			continue
+		}
+
+		active.Participants = append(active.Participants, candidate.GetUUID())
+		joined = append(joined, candidate)
+	}
+
+	return joined, nil
+}
+
+func (w *World) StartWorldBossFight(bossUuid uuid.UUID) {
+	active := w.ActiveBosses[bossUuid]
+	boss := data.WorldBosses[bossUuid]
+
+	delete(w.ActiveBosses, bossUuid)
+
+	location := data.FloorMap.GetLocation(types.EntityLocation{Floor: boss.Floor, Location: boss.Location})
+
+	if location == nil {
+		return
+	}
+
+	entityMap := make(battle.EntityMap)
+
+	for _, participant := range active.Participants {
+		pl, exists := w.Players[participant]
+
+		//Players could have started other fights while waiting
+		if !exists || pl.Meta.FightInstance != nil || pl.GetCurrentHP() <= 0 {
+			continue
+		}
+
+		entityMap[participant] = &battle.EntityEntry{Entity: pl, Side: 0}
+	}
+
+	if len(entityMap) == 0 {
+		w.SendMessage(
+			location.CID,
+			discord.MessageCreate{Content: fmt.Sprintf("Nikt nie stanął do walki, %s odchodzi...", boss.Name)},
+			false,
+		)
+
+		return
+	}
+
+	for range boss.Count {
+		entity := mobs.Spawn(boss.Mob)
+
+		if entity == nil {
+			continue
+		}
+
+		entity.Scale((len(entityMap) - 1) * boss.Scaling)
+
+		entityMap[entity.GetUUID()] = &battle.EntityEntry{Entity: entity, Side: 1}
+	}
+
+	fight := battle.Fight{
+		Entities:       entityMap,
+		DiscordChannel: w.DiscordChannel,
+		Location:       location,
+		Meta:           &battle.FightMeta{WorldBoss: &bossUuid},
+	}
 
-			choice, _ := strconv.Atoi(choiceRaw)
+	fight.Init()
 
-			cic.UpdateMessage(discord.
-				NewMessageUpdateBuilder().
-				ClearContainerComponents().
-				SetContent("Wybrano " + choiceRaw + " przeciwników!").
-				Build(),
-			)
+	fightUUID := w.RegisterFight(&fight)
 
-			go w.PlayerFight(pUuid, location, threadId, enemy.Enemy, choice)
-		})
-	})
-}
+	mentionString := ""
 
-func (w *World) RevivePlayer(p *player.Player) {
-	p.Stats.HP = p.GetStat(types.STAT_HP)
-	p.Stats.CurrentMana = p.GetStat(types.STAT_MANA)
+	for _, entity := range fight.Entities {
+		if entity.Entity.GetFlags()&types.ENTITY_AUTO == 0 {
+			entity.Entity.(*player.Player).Meta.FightInstance = &fightUUID
 
-	w.SendMessage(
-		data.Config.LogChannelID,
-		discord.MessageCreate{
-			Embeds: []discord.Embed{{
-				Title:       "Wskrzeszenie!",
-				Description: fmt.Sprintf("%s zostaje wskrzeszony...", p.GetName()),
-			}},
-		},
-		false,
-	)
+			mentionString += fmt.Sprintf("<@%v>, ", entity.Entity.(*player.Player).Meta.UserID)
+		}
+	}
+
+	w.SendMessage(fight.GetChannelId(), discord.MessageCreate{Content: mentionString[:len(mentionString)-2]}, false)
+
+	go w.ListenForFight(fightUUID)
 }
 
-func (w *World) TickPlayer(p *player.Player) {
-	if p.Meta.FightInstance != nil {
+// Every player gets part of the loot equal to their share of damage dealt
+func (w *World) DistributeBossLoot(fight *battle.Fight, participants []*player.Player, loot []types.Loot, xpMap, goldMap map[uuid.UUID]int, itemMap map[uuid.UUID][]string) {
+	if len(participants) == 0 {
 		return
 	}
 
-	if !data.WorldConfig.Hardcore && p.GetCurrentHP() <= 0 {
-		w.RevivePlayer(p)
+	weights := make([]int, len(participants))
+	overall := 0
 
-		return
+	for idx, pl := range participants {
+		weights[idx] = fight.DamageDealt[pl.GetUUID()]
+		overall += weights[idx]
 	}
 
-	if p.GetCurrentMana() < p.GetStat(types.STAT_MANA) {
-		p.Stats.CurrentMana += 1
+	//Nobody did any damage, split evenly
+	if overall == 0 {
+		for idx := range weights {
+			weights[idx] = 1
+		}
+
+		overall = len(weights)
 	}
 
-	if p.GetCurrentHP() < p.GetStat(types.STAT_HP) {
-		p.Heal(p.GetStat(types.STAT_HP) / 25)
+	for _, entry := range loot {
+		switch entry.Type {
+		case types.LOOT_EXP:
+			shares := SplitShares(entry.Count, weights)
 
-		if p.Meta.WaitToHeal && p.GetCurrentHP() >= p.GetStat(types.STAT_HP) {
-			p.Meta.WaitToHeal = false
+			for idx, pl := range participants {
+				pl.AddEXP(shares[idx])
+				xpMap[pl.GetUUID()] += shares[idx]
+			}
+		case types.LOOT_GOLD:
+			shares := SplitShares(entry.Count, weights)
 
-			client, err := disgo.New(data.Config.Token)
+			for idx, pl := range participants {
+				pl.AddGold(shares[idx])
+				goldMap[pl.GetUUID()] += shares[idx]
+			}
+		case types.LOOT_ITEM:
+			itemUuid, err := uuid.Parse(entry.Item)
 
 			if err != nil {
-				w.SendMessage(
-					data.Config.LogChannelID,
-					discord.MessageCreate{Content: "Nie można wysłać wiadomości do gracza (HP Heal)"},
-					false,
-				)
+				continue
 			}
 
-			ch, err := client.Rest().CreateDMChannel(snowflake.MustParse(p.Meta.UserID))
+			//Weighted pick for every single item
+			for range entry.Count {
+				roll := utils.RandomNumber(1, overall)
+				receiver := participants[len(participants)-1]
 
-			if err != nil {
-				w.SendMessage(
-					data.Config.LogChannelID,
-					discord.NewMessageCreateBuilder().
-						SetContent("Nie można wysłać wiadomości do gracza (HP Heal)").
-						Build(),
-					false,
-				)
-			}
+				for idx, pl := range participants {
+					if roll <= weights[idx] {
+						receiver = pl
+						break
+					}
 
-			_, err = client.Rest().CreateMessage(
-				ch.ID(), discord.MessageCreate{Content: "Twoja postać ma już 100% HP, baw się dobrze!"},
-			)
+					roll -= weights[idx]
+				}
 
-			if err != nil {
-				w.SendMessage(
-					data.Config.LogChannelID,
-					discord.MessageCreate{Content: "Nie można wysłać wiadomości do gracza (HP Heal)"},
-					false,
-				)
+				given, err := receiver.GiveItem(itemUuid, 1)
+
+				if err != nil {
+					continue
+				}
+
+				for _, item := range given {
+					itemMap[receiver.GetUUID()] = append(
+						itemMap[receiver.GetUUID()], fmt.Sprintf("%dx %s", item.Count, item.DisplayName()),
+					)
+				}
 			}
 		}
 	}
 }
 
-func (w *World) StartClock() {
-	counter := 0
+func QuestProgressText(p *player.Player, questUuids []uuid.UUID) string {
+	text := ""
 
-	for range time.Tick(1 * time.Minute) {
-		for _, player := range w.Players {
-			w.TickPlayer(player)
-		}
+	for _, questUuid := range questUuids {
+		quest := data.Quests[questUuid]
+		progress := p.GetQuestProgress(quest)
 
-		counter++
+		text += fmt.Sprintf("%s - %s:", p.GetName(), quest.Name)
 
-		if counter >= 15 {
-			counter = 0
+		for idx, objective := range quest.Objectives {
+			text += fmt.Sprintf(" %d/%d", progress[idx], objective.Count)
+		}
 
-			w.CreateBackup()
+		if p.IsQuestDone(quest) {
+			text += fmt.Sprintf(" (ukończone, wróć do: %s)", quest.Giver)
 		}
+
+		text += "\n"
 	}
+
+	return text
 }
 
 func (w *World) RegisterFight(fight *battle.Fight) uuid.UUID {
@@ -373,6 +1167,10 @@ func (w *World) ListenForFight(fightUuid uuid.UUID) {
 					false,
 				)
 
+				if fight.Meta.Duel != nil {
+					w.FinishDuel(*fight.Meta.Duel, uuid.Nil)
+				}
+
 				w.DeregisterFight(fightUuid)
 
 				return
@@ -391,6 +1189,7 @@ func (w *World) ListenForFight(fightUuid uuid.UUID) {
 			}
 
 			if allAuto {
+				w.HandleFightDeaths(fight)
 				w.DeregisterFight(fightUuid)
 
 				wonSideText := ""
@@ -417,6 +1216,8 @@ func (w *World) ListenForFight(fightUuid uuid.UUID) {
 
 			xpMap := make(map[uuid.UUID]int)
 			goldMap := make(map[uuid.UUID]int)
+			itemMap := make(map[uuid.UUID][]string)
+			lootModeText := ""
 
 			for _, entity := range fight.Entities {
 				if entity.Side == wonSideIDX {
@@ -426,9 +1227,36 @@ func (w *World) ListenForFight(fightUuid uuid.UUID) {
 				enemies = append(enemies, entity.Entity)
 			}
 
-			if fight.Meta.Tournament == nil {
+			if fight.Meta.WorldBoss != nil {
+				bossLoot := make([]types.Loot, 0)
+
+				for _, enemy := range enemies {
+					bossLoot = append(bossLoot, enemy.GetLoot()...)
+				}
+
+				bossLoot = append(bossLoot, data.WorldBosses[*fight.Meta.WorldBoss].Loot...)
+
+				participants := make([]*player.Player, 0)
+
+				for _, entity := range wonEntities {
+					if entity.GetFlags()&types.ENTITY_AUTO == 0 {
+						participants = append(participants, entity.(*player.Player))
+					}
+				}
+
+				w.DistributeBossLoot(fight, participants, bossLoot, xpMap, goldMap, itemMap)
+			} else if fight.Meta.Duel != nil {
+				duel := w.Duels[*fight.Meta.Duel]
+
+				if duel != nil {
+					goldMap[wonEntities[0].GetUUID()] = duel.Stake
+				}
+
+				w.FinishDuel(*fight.Meta.Duel, wonEntities[0].GetUUID())
+			} else if fight.Meta.Tournament == nil {
 				overallXp := 0
 				overallGold := 0
+				itemLoot := make([]types.Loot, 0)
 
 				for _, entity := range fight.Entities {
 					if entity.Side == wonSideIDX {
@@ -443,69 +1271,48 @@ func (w *World) ListenForFight(fightUuid uuid.UUID) {
 							overallXp += loot.Count
 						case types.LOOT_GOLD:
 							overallGold += loot.Count
+						case types.LOOT_ITEM:
+							itemLoot = append(itemLoot, loot)
 						}
 					}
 				}
 
-				var partyInfo *player.PartialParty
+				var partyObj *party.Party
 
 				for _, entity := range wonEntities {
 					if entity.GetFlags()&types.ENTITY_AUTO != 0 {
 						continue
 					}
 
-					partyInfo = entity.(*player.Player).Meta.Party
+					if partyInfo := entity.(*player.Player).Meta.Party; partyInfo != nil {
+						partyObj = w.Parties[partyInfo.UUID]
+					}
+
 					break
 				}
 
-				if partyInfo != nil {
-					partyData := w.Parties[partyInfo.UUID]
-
-					for _, member := range partyData.Players {
-						player := w.Players[member.PlayerUuid]
-
-						player.AddEXP(overallXp / len(partyData.Players))
-
-						if _, ok := xpMap[member.PlayerUuid]; !ok {
-							xpMap[member.PlayerUuid] = overallXp / len(partyData.Players)
-						} else {
-							xpMap[member.PlayerUuid] += overallXp / len(partyData.Players)
-						}
-
-						player.AddGold(overallGold / len(partyData.Players))
+				//Players that ran away aren't in the fight anymore
+				lootReceivers := make([]*player.Player, 0)
 
-						if _, ok := goldMap[member.PlayerUuid]; !ok {
-							goldMap[member.PlayerUuid] = overallGold / len(partyData.Players)
-						} else {
-							goldMap[member.PlayerUuid] += overallGold / len(partyData.Players)
-						}
+				for _, entity := range wonEntities {
+					if entity.GetFlags()&types.ENTITY_AUTO != 0 || entity.GetCurrentHP() <= 0 {
+						continue
 					}
-				} else {
-					for _, entity := range wonEntities {
-						entityUuid := entity.GetUUID()
-
-						if entity.GetFlags()&types.ENTITY_AUTO != 0 {
-							continue
-						}
 
-						player := w.Players[entityUuid]
-
-						player.AddEXP(overallXp)
+					lootReceivers = append(lootReceivers, entity.(*player.Player))
+				}
 
-						if _, ok := xpMap[entityUuid]; !ok {
-							xpMap[entityUuid] = overallXp
-						} else {
-							xpMap[entityUuid] += overallXp
-						}
+				w.DistributeLoot(fight, lootReceivers, partyObj, overallXp, overallGold, itemLoot, xpMap, goldMap, itemMap)
 
-						player.AddGold(overallGold)
+				if partyObj != nil {
+					lootModeText = party.LootModeToString[partyObj.LootMode]
+				}
+			}
 
-						if _, ok := goldMap[entityUuid]; !ok {
-							goldMap[entityUuid] = overallGold
-						} else {
-							goldMap[entityUuid] += overallGold
-						}
-					}
+			for playerUuid, xp := range xpMap {
+				if pl, exists := w.Players[playerUuid]; exists {
+					pl.AddFuryXP(xp)
+					w.AddGuildActivity(pl, xp)
 				}
 			}
 
@@ -542,11 +1349,21 @@ func (w *World) ListenForFight(fightUuid uuid.UUID) {
 					goldGotten = 0
 				}
 
-				lootSummaryText += fmt.Sprintf("%v - XP: %d, Złoto: %d\n", entity.GetName(), xpGotten, goldGotten)
+				lootSummaryText += fmt.Sprintf("%v - XP: %d, Złoto: %d", entity.GetName(), xpGotten, goldGotten)
+
+				if itemsGotten, exists := itemMap[entity.GetUUID()]; exists {
+					lootSummaryText += ", Przedmioty: " + strings.Join(itemsGotten, ", ")
+				}
+
+				lootSummaryText += "\n"
 			}
 
 			lootSummaryText = lootSummaryText[:len(lootSummaryText)-1]
 
+			if lootModeText != "" {
+				lootSummaryText += "\nTryb łupów: " + lootModeText
+			}
+
 			w.SendMessage(
 				channelId,
 				discord.MessageCreate{Embeds: []discord.Embed{discord.
@@ -559,9 +1376,76 @@ func (w *World) ListenForFight(fightUuid uuid.UUID) {
 				false,
 			)
 
+			killedMobs := make([]string, 0)
+
+			for _, enemy := range enemies {
+				if mob, isMob := enemy.(*mobs.MobEntity); isMob {
+					killedMobs = append(killedMobs, mob.Id)
+				}
+			}
+
+			locationName := ""
+
+			if fight.Location != nil {
+				locationName = fight.Location.Name
+			}
+
+			questText := ""
+			winners := make([]*player.Player, 0)
+
+			for _, entity := range wonEntities {
+				if entity.GetFlags()&types.ENTITY_AUTO != 0 {
+					continue
+				}
+
+				pl := entity.(*player.Player)
+
+				winners = append(winners, pl)
+
+				var changed []uuid.UUID
+
+				if fight.Meta.Tournament != nil {
+					changed = pl.RecordTournamentWin(data.Quests)
+				} else {
+					changed = pl.RecordKills(data.Quests, killedMobs, locationName)
+
+					if pl.Fury != nil {
+						pl.Fury.RecordKills(killedMobs)
+					}
+				}
+
+				questText += QuestProgressText(pl, changed)
+			}
+
+			if fight.Meta.Tournament == nil {
+				w.HandleFloorBoss(fight.Location, killedMobs, winners)
+			}
+
+			if questText != "" {
+				w.SendMessage(
+					channelId,
+					discord.MessageCreate{Embeds: []discord.Embed{discord.
+						NewEmbedBuilder().
+						SetTitle("Postęp zadań").
+						SetDescription(strings.TrimSuffix(questText, "\n")).
+						Build(),
+					}},
+					false,
+				)
+			}
+
+			w.HandleFightDeaths(fight)
+
 			if fight.Meta.Tournament != nil {
+				//No death penalty in tournaments
+				for _, entry := range fight.Entities {
+					if entry.Entity.GetFlags()&types.ENTITY_AUTO == 0 && entry.Entity.GetCurrentHP() <= 0 {
+						entry.Entity.(*player.Player).Stats.HP = 1
+					}
+				}
+
 				w.Tournaments[fight.Meta.Tournament.Tournament].ExternalChannel <- tournament.MatchFinishedData{
-					Winner: wonEntities[0].GetUUID(),
+					Winner: fight.Meta.Tournament.Sides[wonSideIDX],
 				}
 			}
 		case battle.MSG_FIGHT_START:
@@ -808,26 +1692,26 @@ func (w *World) GetPlayer(uid string) *player.Player {
 	return nil
 }
 
-func (w *World) RegisterTournament(tournament tournament.Tournament) {
-	w.Tournaments[tournament.Uuid] = &tournament
+func (w *World) RegisterTournament(tournamentObj tournament.Tournament) {
+	w.Tournaments[tournamentObj.Uuid] = &tournamentObj
 
 	var playerText string = ""
-	if tournament.MaxPlayers == -1 {
+	if tournamentObj.MaxPlayers == -1 {
 		playerText = "Nieograniczona"
 	} else {
-		playerText = fmt.Sprintf("%v/%v", len(tournament.Participants), tournament.MaxPlayers)
+		playerText = fmt.Sprintf("%v/%v", len(tournamentObj.Participants), tournamentObj.MaxPlayers)
 	}
 
 	w.SendMessage(
-		"1225150345009827841",
+		TournamentChannelID,
 		discord.NewMessageCreateBuilder().
 			AddEmbeds(discord.NewEmbedBuilder().
 				SetTitle("Nowy turniej!").
-				SetDescriptionf("Zapisy na turniej `%v` otwarte", tournament.Name).
+				SetDescription(TournamentDescription(&tournamentObj)).
 				SetFooterText("Ilość miejsc: "+playerText).
 				Build()).
 			AddActionRow(
-				discord.NewPrimaryButton("Dołącz", "t/join/"+tournament.Uuid.String()),
+				discord.NewPrimaryButton("Dołącz", "t/join/"+tournamentObj.Uuid.String()),
 			).
 			Build(),
 		false,
@@ -849,15 +1733,60 @@ func (w *World) JoinTournament(uuid uuid.UUID, player *player.Player) error {
 		return errors.New("tournament is full")
 	}
 
-	tournamentObj.Participants = append(tournamentObj.Participants, player.GetUUID())
+	if tournamentObj.IsTeamMode() {
+		if err := w.registerTournamentTeam(tournamentObj, player); err != nil {
+			return err
+		}
+	} else {
+		tournamentObj.Participants = append(tournamentObj.Participants, player.GetUUID())
+	}
 
-	if tournamentObj.MaxPlayers != -1 && len(tournamentObj.Participants) == tournamentObj.MaxPlayers {
+	//Scheduled tournaments wait for their start time and check in
+	if tournamentObj.StartTime == nil && tournamentObj.MaxPlayers != -1 && len(tournamentObj.Participants) == tournamentObj.MaxPlayers {
 		w.StartTournament(uuid)
 	}
 
 	return nil
 }
 
+// Whole party joins as a team, members past team size are substitutes
+func (w *World) registerTournamentTeam(tournamentObj *tournament.Tournament, leader *player.Player) error {
+	if leader.Meta.Party == nil {
+		return errors.New("NOT_IN_PARTY")
+	}
+
+	partyObj, exists := w.Parties[leader.Meta.Party.UUID]
+
+	if !exists {
+		return errors.New("NOT_IN_PARTY")
+	}
+
+	if partyObj.Leader != leader.GetUUID() {
+		return errors.New("NOT_LEADER")
+	}
+
+	//Leader always starts, the rest goes in party order
+	members := []uuid.UUID{leader.GetUUID()}
+
+	for _, member := range partyObj.Players {
+		if member.PlayerUuid != leader.GetUUID() {
+			members = append(members, member.PlayerUuid)
+		}
+	}
+
+	_, err := tournamentObj.RegisterTeam("Drużyna "+leader.GetName(), leader.Meta.Party.UUID, members)
+
+	return err
+}
+
+func TournamentFormatText(tournamentObj *tournament.Tournament) string {
+	if tournamentObj.IsTeamMode() {
+		return fmt.Sprintf("%v, drużyny %vv%v", tournament.FormatToString[tournamentObj.Format], tournamentObj.TeamSize, tournamentObj.TeamSize)
+	}
+
+	return tournament.FormatToString[tournamentObj.Format]
+}
+
 func (w *World) StartTournament(tUuid uuid.UUID) error {
 	tournamentObj := w.Tournaments[tUuid]
 
@@ -869,47 +1798,46 @@ func (w *World) StartTournament(tUuid uuid.UUID) error {
 		return errors.New("tournament running")
 	}
 
-	if tournamentObj.MaxPlayers != -1 && len(tournamentObj.Participants) < 2 {
-		return errors.New("not enough players")
+	fightingLocation := data.FloorMap.FindLocation(func(loc types.Location) bool {
+		return slices.Contains(loc.Flags, "arena")
+	})
+
+	if fightingLocation == nil {
+		return errors.New("arena not found")
 	}
 
-	tournamentObj.State = tournament.Running
+	arenaChannel, err := snowflake.Parse(fightingLocation.CID)
 
-	var fightingLocation types.Location
+	if err != nil {
+		return err
+	}
 
-	for _, floor := range data.FloorMap {
-		for _, location := range floor.Locations {
-			for _, effect := range location.Flags {
-				if effect == "arena" {
-					fightingLocation = location
-				}
-			}
-		}
+	w.dropNoShows(tournamentObj)
+
+	if len(tournamentObj.Participants) < 2 {
+		return errors.New("not enough players")
 	}
 
 	client, err := disgo.New(data.Config.Token)
 
 	if err != nil {
-		panic(err)
+		return err
 	}
 
-	msg, err := client.Rest().CreateMessage(
-		snowflake.MustParse(fightingLocation.CID),
-		discord.MessageCreate{Content: "Turniej rozpoczęty!"},
-	)
+	msg, err := client.Rest().CreateMessage(arenaChannel, discord.MessageCreate{Content: "Turniej rozpoczęty!"})
 
 	if err != nil {
-		panic(err)
+		return err
 	}
 
 	thread, err := client.Rest().CreateThreadFromMessage(
-		snowflake.MustParse(fightingLocation.CID),
+		arenaChannel,
 		msg.ID,
 		discord.ThreadCreateFromMessage{Name: "Turniej"},
 	)
 
 	if err != nil {
-		panic(err)
+		return err
 	}
 
 	tournamentObj.ExternalChannel = make(chan tournament.TournamentEventData)
@@ -923,71 +1851,22 @@ func (w *World) StartTournament(tUuid uuid.UUID) error {
 		false,
 	)
 
-	matches := make([]*tournament.TournamentMatch, 0)
-
-	var participants = tournamentObj.Participants
-
-	if len(tournamentObj.Participants)%2 != 0 {
-		luckyPlayer := utils.RandomNumber(0, len(tournamentObj.Participants)-1)
-
-		matches = append(matches, &tournament.TournamentMatch{
-			Players: []uuid.UUID{tournamentObj.Participants[luckyPlayer]},
-			Winner:  &tournamentObj.Participants[luckyPlayer],
-			State:   tournament.FinishedMatch,
-		})
-
-		w.SendMessage(
-			tournamentObj.Channel,
-			discord.NewMessageCreateBuilder().
-				SetContentf(
-					"Szczęśliwy gracz to <@%v>\nNie musisz walczyć w tej rundzie i możesz spokojnie oglądać!",
-					w.Players[tournamentObj.Participants[luckyPlayer]].Meta.UserID,
-				).
-				Build(),
-			false,
-		)
-
-		participants = slices.Delete(participants, luckyPlayer, luckyPlayer+1)
-	}
+	//Hackery shuffle, participants are seeded in the drawn order
+	participants := tournamentObj.Participants
 
-	//Hackery shuffle
 	for i := range participants {
 		j := utils.RandomNumber(0, len(participants)-1)
 
 		participants[i], participants[j] = participants[j], participants[i]
 	}
 
-	for i := 0; i < len(participants); i += 2 {
-		matches = append(matches, &tournament.TournamentMatch{
-			Players: []uuid.UUID{participants[i], participants[i+1]},
-			Winner:  nil,
-			State:   tournament.BeforeMatch,
-		})
-
-		player0 := w.Players[participants[i]]
-		player1 := w.Players[participants[i+1]]
-
-		w.SendMessage(
-			tournamentObj.Channel,
-			discord.MessageCreate{
-				Content: fmt.Sprintf(
-					"Los pociągnięty!\nMecz #%v: %v vs %v", (i/2)+1, player0.GetName(), player1.GetName(),
-				),
-			},
-			false,
-		)
+	if err := tournamentObj.Start(); err != nil {
+		return err
 	}
 
-	tournamentObj.Stages = append(tournamentObj.Stages, &tournament.TournamentStage{
-		Matches: matches,
-	})
-
-	for idx, match := range tournamentObj.Stages[0].Matches {
-		if match.State == tournament.BeforeMatch {
-			w.StartMatch(tUuid, idx)
-			break
-		}
-	}
+	w.AnnounceStage(tUuid)
+
+	w.StartNextMatch(tUuid)
 
 	go w.ListenForTournament(tUuid)
 
@@ -1014,58 +1893,186 @@ func (w *World) ListenForTournament(tUuid uuid.UUID) {
 
 			w.FinishMatch(tUuid, matchData)
 
-			currentStage := tournamentObj.Stages[len(tournamentObj.Stages)-1]
+			if !tournamentObj.GetCurrentStage().IsFinished() {
+				w.StartNextMatch(tUuid)
+				continue
+			}
+
+			stageCount := len(tournamentObj.Stages)
 
-			allFinished := true
+			w.NextStage(tUuid)
 
-			for _, match := range currentStage.Matches {
-				if match.State != tournament.FinishedMatch {
-					allFinished = false
-					break
+			if tournamentObj.State == tournament.Finished {
+				winnerText := w.tournamentWinnerText(tournamentObj, *tournamentObj.GetWinner())
+
+				messageBuilder := discord.NewMessageCreateBuilder().
+					SetContentf("Turniej zakończony! Wygrał %v", winnerText).
+					AddEmbeds(w.StandingsEmbed(tournamentObj))
+
+				if bracket := w.BracketFile(tournamentObj); bracket != nil {
+					messageBuilder.AddFiles(bracket)
 				}
+
+				w.SendMessage(tournamentObj.Channel, messageBuilder.Build(), false)
+
+				w.FinishTournament(tUuid)
+
+				return
 			}
 
-			if allFinished {
-				w.NextStage(tUuid)
+			if tournamentObj.Format == tournament.RoundRobin || tournamentObj.Format == tournament.Swiss {
+				w.SendMessage(
+					tournamentObj.Channel,
+					discord.NewMessageCreateBuilder().AddEmbeds(w.StandingsEmbed(tournamentObj)).Build(),
+					false,
+				)
+			}
 
-				if tournamentObj.State == tournament.Finished {
-					currentStage := tournamentObj.Stages[len(tournamentObj.Stages)-1]
+			if len(tournamentObj.Stages) > stageCount {
+				w.AnnounceStage(tUuid)
+			}
 
-					matchWinner := currentStage.Matches[0].Winner
+			w.StartNextMatch(tUuid)
+		}
+	}
+}
 
-					player := w.Players[*matchWinner]
+func (w *World) StartNextMatch(tUuid uuid.UUID) {
+	tournamentObj := w.Tournaments[tUuid]
 
-					w.SendMessage(
-						tournamentObj.Channel,
-						discord.NewMessageCreateBuilder().
-							SetContentf("Turniej zakończony! Wygrał %v (<@%v>)", player.GetName(), player.Meta.UserID).
-							Build(),
-						false,
-					)
+	if tournamentObj == nil || tournamentObj.State != tournament.Running {
+		return
+	}
 
-					w.FinishTournament(tUuid)
+	for idx, match := range tournamentObj.GetCurrentStage().Matches {
+		if match.State == tournament.BeforeMatch {
+			w.StartMatch(tUuid, idx)
+			w.RemindNextMatch(tUuid)
+			break
+		}
+	}
+}
 
-					return
-				}
+// Participant is a team uuid in team tournaments
+func (w *World) tournamentPlayerName(tournamentObj *tournament.Tournament, participant uuid.UUID) string {
+	if team, exists := tournamentObj.Teams[participant]; exists {
+		return team.Name
+	}
 
-				currentStage := tournamentObj.Stages[len(tournamentObj.Stages)-1]
+	if pl, exists := w.Players[participant]; exists {
+		return pl.GetName()
+	}
 
-				for idx, match := range currentStage.Matches {
-					if match.State == tournament.BeforeMatch {
-						w.StartMatch(tUuid, idx)
-						break
-					}
-				}
-			} else {
-				for idx, match := range currentStage.Matches {
-					if match.State == tournament.BeforeMatch {
-						w.StartMatch(tUuid, idx)
-						break
-					}
-				}
+	return "Nieznany gracz"
+}
+
+func (w *World) tournamentWinnerText(tournamentObj *tournament.Tournament, participant uuid.UUID) string {
+	if team, exists := tournamentObj.Teams[participant]; exists {
+		mentions := make([]string, 0)
+
+		for _, member := range team.Members {
+			if pl, exists := w.Players[member]; exists {
+				mentions = append(mentions, fmt.Sprintf("<@%v>", pl.Meta.UserID))
 			}
 		}
+
+		return fmt.Sprintf("%v (%v)", team.Name, strings.Join(mentions, ", "))
+	}
+
+	if pl, exists := w.Players[participant]; exists {
+		return fmt.Sprintf("%v (<@%v>)", pl.GetName(), pl.Meta.UserID)
+	}
+
+	return "postać już nie żyje"
+}
+
+// Players fighting for the participant, substitutes fill in for starters that are dead or busy
+func (w *World) tournamentLineup(tournamentObj *tournament.Tournament, participant uuid.UUID) []*player.Player {
+	lineup := make([]*player.Player, 0)
+
+	if !tournamentObj.IsTeamMode() {
+		if pl, exists := w.Players[participant]; exists {
+			lineup = append(lineup, pl)
+		}
+
+		return lineup
+	}
+
+	available := func(playerUuid uuid.UUID) bool {
+		pl, exists := w.Players[playerUuid]
+
+		return exists && pl.Meta.FightInstance == nil && pl.GetCurrentHP() > 0
+	}
+
+	for _, playerUuid := range tournamentObj.GetLineup(participant, available) {
+		lineup = append(lineup, w.Players[playerUuid])
+	}
+
+	return lineup
+}
+
+func (w *World) AnnounceStage(tUuid uuid.UUID) {
+	tournamentObj := w.Tournaments[tUuid]
+
+	if tournamentObj == nil {
+		return
+	}
+
+	stage := tournamentObj.GetCurrentStage()
+	matchesText := ""
+
+	for idx, match := range stage.Matches {
+		if match.IsBye() {
+			matchesText += fmt.Sprintf("Mecz #%v: %v - wolny los\n", idx+1, w.tournamentPlayerName(tournamentObj, match.Players[0]))
+			continue
+		}
+
+		odds := w.GetMatchOdds(tournamentObj, match)
+
+		matchesText += fmt.Sprintf(
+			"Mecz #%v: %v (%v) vs %v (%v)\n",
+			idx+1,
+			w.tournamentPlayerName(tournamentObj, match.Players[0]), tournament.OddsToString(odds[0]),
+			w.tournamentPlayerName(tournamentObj, match.Players[1]), tournament.OddsToString(odds[1]),
+		)
+	}
+
+	messageBuilder := discord.NewMessageCreateBuilder().
+		AddEmbeds(discord.NewEmbedBuilder().
+			SetTitlef("Runda %v", stage.IDX+1).
+			SetDescription(strings.TrimSuffix(matchesText, "\n")).
+			SetFooterText(tournament.FormatToString[tournamentObj.Format]).
+			Build(),
+		)
+
+	if bracket := w.BracketFile(tournamentObj); bracket != nil {
+		messageBuilder.AddFiles(bracket)
+	}
+
+	w.SendMessage(tournamentObj.Channel, messageBuilder.Build(), false)
+}
+
+func (w *World) StandingsEmbed(tournamentObj *tournament.Tournament) discord.Embed {
+	standingsText := ""
+
+	for _, standing := range tournamentObj.GetStandings() {
+		line := fmt.Sprintf("%v. %v - %v W / %v P", standing.Place, w.tournamentPlayerName(tournamentObj, standing.Player), standing.Wins, standing.Losses)
+
+		switch tournamentObj.Format {
+		case tournament.RoundRobin:
+			line += fmt.Sprintf(" (%v pkt, SB %v)", standing.Points, standing.SonnebornBerger)
+		case tournament.Swiss:
+			line += fmt.Sprintf(" (%v pkt, Buchholz %v)", standing.Points, standing.Buchholz)
+		}
+
+		standingsText += line + "\n"
 	}
+
+	return discord.NewEmbedBuilder().
+		SetTitle("Tabela").
+		SetDescription(strings.TrimSuffix(standingsText, "\n")).
+		SetFooterText(tournament.FormatToString[tournamentObj.Format]).
+		Build()
 }
 
 func (w *World) StartMatch(tUuid uuid.UUID, matchIdx int) {
@@ -1093,13 +2100,33 @@ func (w *World) StartMatch(tUuid uuid.UUID, matchIdx int) {
 
 	match.State = tournament.RunningMatch
 
-	player0 := w.Players[match.Players[0]]
-	player1 := w.Players[match.Players[1]]
+	lineup0 := w.tournamentLineup(tournamentObj, match.Players[0])
+	lineup1 := w.tournamentLineup(tournamentObj, match.Players[1])
+
+	//Fallen characters and teams without anyone to field forfeit their matches
+	if len(lineup0) == 0 || len(lineup1) == 0 {
+		winner := match.Players[0]
+
+		if len(lineup0) == 0 {
+			winner = match.Players[1]
+		}
+
+		w.RefundBets(func(bet *TournamentBet) bool {
+			return bet.Tournament == tUuid && bet.Stage == stage.IDX && bet.Match == matchIdx
+		}, fmt.Sprintf("mecz w turnieju `%v` zakończył się walkowerem", tournamentObj.Name))
+
+		go func() { tournamentObj.ExternalChannel <- tournament.MatchFinishedData{Winner: winner} }()
+
+		return
+	}
 
 	entityMap := make(battle.EntityMap)
 
-	entityMap[player0.GetUUID()] = &battle.EntityEntry{Entity: player0, Side: 0}
-	entityMap[player1.GetUUID()] = &battle.EntityEntry{Entity: player1, Side: 1}
+	for side, lineup := range [][]*player.Player{lineup0, lineup1} {
+		for _, pl := range lineup {
+			entityMap[pl.GetUUID()] = &battle.EntityEntry{Entity: pl, Side: side}
+		}
+	}
 
 	fightingLocation := data.FloorMap.FindLocation(func(loc types.Location) bool {
 		return slices.Contains(loc.Flags, "arena")
@@ -1110,8 +2137,12 @@ func (w *World) StartMatch(tUuid uuid.UUID, matchIdx int) {
 		DiscordChannel: w.DiscordChannel,
 		Location:       fightingLocation,
 		Meta: &battle.FightMeta{
-			ThreadId:   "",
-			Tournament: &battle.TournamentData{Tournament: tUuid, Location: w.Tournaments[tUuid].Channel},
+			ThreadId: "",
+			Tournament: &battle.TournamentData{
+				Tournament: tUuid,
+				Location:   w.Tournaments[tUuid].Channel,
+				Sides:      [2]uuid.UUID{match.Players[0], match.Players[1]},
+			},
 		},
 	}
 
@@ -1119,8 +2150,12 @@ func (w *World) StartMatch(tUuid uuid.UUID, matchIdx int) {
 
 	fightUUID := w.RegisterFight(&fight)
 
-	player0.Meta.FightInstance = &fightUUID
-	player1.Meta.FightInstance = &fightUUID
+	deadline := time.Now().Add(time.Duration(data.TournamentConfig.MatchTime) * time.Minute)
+	match.Deadline = &deadline
+
+	for _, pl := range append(lineup0, lineup1...) {
+		pl.Meta.FightInstance = &fightUUID
+	}
 
 	go w.ListenForFight(fightUUID)
 }
@@ -1132,6 +2167,16 @@ func (w *World) FinishMatch(tUuid uuid.UUID, winner uuid.UUID) {
 		return
 	}
 
+	if stage := tournamentObj.GetCurrentStage(); stage != nil {
+		for matchIdx, match := range stage.Matches {
+			if match.State == tournament.FinishedMatch || match.IsBye() || !slices.Contains(match.Players, winner) {
+				continue
+			}
+
+			w.SettleBets(tUuid, stage.IDX, matchIdx, winner)
+		}
+	}
+
 	tournamentObj.FinishMatch(winner)
 }
 
@@ -1156,7 +2201,21 @@ func (w *World) FinishTournament(tUuid uuid.UUID) {
 		return
 	}
 
+	prizeText := w.AwardTournamentPrizes(tournamentObj)
+
+	w.TournamentHistory = append(w.TournamentHistory, tournament.NewHistoryEntry(tournamentObj, w.tournamentNames(tournamentObj)))
+
 	delete(w.Tournaments, tUuid)
+
+	if prizeText != "" {
+		w.SendMessage(
+			tournamentObj.Channel,
+			discord.NewMessageCreateBuilder().
+				AddEmbeds(discord.NewEmbedBuilder().SetTitle("Nagrody").SetDescription(prizeText).Build()).
+				Build(),
+			false,
+		)
+	}
 }
 
 func (w *World) Serialize() map[string]any {
@@ -1178,10 +2237,73 @@ func (w *World) Serialize() map[string]any {
 		partyData[key] = party.Serialize()
 	}
 
+	//Fights aren't persisted, stakes of running duels get refunded on load
+	duelEscrow := make([]map[string]any, 0)
+
+	for _, duel := range w.Duels {
+		if duel.Accepted && duel.Stake > 0 {
+			duelEscrow = append(duelEscrow, map[string]any{
+				"challenger": duel.Challenger.String(), "target": duel.Target.String(), "stake": duel.Stake,
+			})
+		}
+	}
+
+	bossTimers := make(map[string]int)
+
+	for key, timer := range w.BossTimers {
+		bossTimers[key.String()] = timer
+	}
+
+	activeBosses := make([]map[string]any, 0)
+
+	for _, active := range w.ActiveBosses {
+		activeBosses = append(activeBosses, active.Serialize())
+	}
+
+	graveyardData := make([]map[string]any, 0)
+
+	for _, fallen := range w.Graveyard {
+		graveyardData = append(graveyardData, fallen.Serialize())
+	}
+
+	inviteData := make(map[string]any)
+
+	for key, invite := range w.PartyInvites {
+		inviteData[key.String()] = invite.Serialize()
+	}
+
+	guildData := make(map[string]any)
+
+	for key, guild := range w.Guilds {
+		guildData[key.String()] = guild.Serialize()
+	}
+
+	historyData := make([]map[string]any, 0)
+
+	for _, entry := range w.TournamentHistory {
+		historyData = append(historyData, entry.Serialize())
+	}
+
+	//Bets on matches of resumed tournaments are kept, the rest get refunded on load
+	betData := make([]map[string]any, 0)
+
+	for _, bet := range w.Bets {
+		betData = append(betData, bet.Serialize())
+	}
+
 	return map[string]any{
-		"players":     playerData,
-		"parties":     partyData,
-		"tournaments": tournamentData,
+		"bets":               betData,
+		"tournament_history": historyData,
+		"guilds":             guildData,
+		"invites":            inviteData,
+		"graveyard":          graveyardData,
+		"players":            playerData,
+		"parties":            partyData,
+		"tournaments":        tournamentData,
+		"floors":             w.UnlockedFloors,
+		"boss_timers":        bossTimers,
+		"active_bosses":      activeBosses,
+		"duels":              duelEscrow,
 	}
 }
 
@@ -1324,34 +2446,238 @@ func (w *World) LoadBackupData(data []byte) error {
 	for key, partyData := range backupData["parties"].(map[string]any) {
 		deserializedParty := party.Deserialize(partyData.(map[string]any))
 
-		for _, member := range deserializedParty.Players {
-			w.Players[member.PlayerUuid].Meta.Party = &player.PartialParty{
-				UUID:         uuid.MustParse(key),
-				Role:         member.Role,
-				MembersCount: len(deserializedParty.Players),
-			}
+		w.Parties[uuid.MustParse(key)] = deserializedParty
+
+		w.SyncParty(uuid.MustParse(key))
+	}
+
+	w.Guilds = make(map[uuid.UUID]*guild.Guild)
+
+	if rawGuilds, ok := backupData["guilds"].(map[string]any); ok {
+		for key, guildData := range rawGuilds {
+			w.Guilds[uuid.MustParse(key)] = guild.Deserialize(guildData.(map[string]any))
+
+			w.SyncGuild(uuid.MustParse(key))
 		}
+	}
 
-		w.Parties[uuid.MustParse(key)] = deserializedParty
+	for _, pl := range w.Players {
+		if pl.Meta.Guild != nil {
+			if _, exists := w.Guilds[pl.Meta.Guild.UUID]; !exists {
+				pl.Meta.Guild = nil
+			}
+		}
 	}
 
 	for _, tData := range backupData["tournaments"].([]any) {
 		parsedData := tournament.Deserialize(tData.(map[string]any))
 
 		w.Tournaments[parsedData.Uuid] = &parsedData
+
+		w.ResumeTournament(parsedData.Uuid)
+	}
+
+	w.TournamentHistory = make([]*tournament.HistoryEntry, 0)
+
+	if rawHistory, ok := backupData["tournament_history"].([]any); ok {
+		for _, entryData := range rawHistory {
+			w.TournamentHistory = append(w.TournamentHistory, tournament.DeserializeHistoryEntry(entryData.(map[string]any)))
+		}
+	}
+
+	w.UnlockedFloors = make([]string, 0)
+
+	if rawFloors, ok := backupData["floors"].([]any); ok {
+		for _, floor := range rawFloors {
+			w.UnlockedFloors = append(w.UnlockedFloors, floor.(string))
+		}
+	} else {
+		w.unlockLegacyFloors()
+	}
+
+	w.ApplyFloorUnlocks()
+
+	if rawDuels, ok := backupData["duels"].([]any); ok {
+		for _, rawDuel := range rawDuels {
+			duel := rawDuel.(map[string]any)
+			stake := int(duel["stake"].(float64))
+
+			for _, key := range []string{"challenger", "target"} {
+				if pl, exists := w.Players[uuid.MustParse(duel[key].(string))]; exists {
+					pl.AddGold(stake)
+				}
+			}
+		}
+	}
+
+	w.Bets = make(map[uuid.UUID]*TournamentBet)
+
+	if rawBets, ok := backupData["bets"].([]any); ok {
+		for _, rawBet := range rawBets {
+			bet := DeserializeTournamentBet(rawBet.(map[string]any))
+
+			if w.isBetOpen(bet) {
+				w.Bets[uuid.New()] = bet
+				continue
+			}
+
+			if pl, exists := w.Players[bet.Bettor]; exists {
+				pl.AddGold(bet.Amount)
+			}
+		}
+	}
+
+	w.Graveyard = make([]*FallenCharacter, 0)
+
+	if rawGraveyard, ok := backupData["graveyard"].([]any); ok {
+		for _, fallen := range rawGraveyard {
+			w.Graveyard = append(w.Graveyard, DeserializeFallenCharacter(fallen.(map[string]any)))
+		}
+	}
+
+	w.PartyInvites = make(map[uuid.UUID]*PartyInvite)
+
+	if rawInvites, ok := backupData["invites"].(map[string]any); ok {
+		for key, invite := range rawInvites {
+			w.PartyInvites[uuid.MustParse(key)] = DeserializePartyInvite(invite.(map[string]any))
+		}
+	}
+
+	w.TickPartyInvites()
+
+	w.BossTimers = make(map[uuid.UUID]int)
+
+	if rawTimers, ok := backupData["boss_timers"].(map[string]any); ok {
+		for key, timer := range rawTimers {
+			if bossUuid, err := uuid.Parse(key); err == nil {
+				w.BossTimers[bossUuid] = int(timer.(float64))
+			}
+		}
+	}
+
+	w.ActiveBosses = make(map[uuid.UUID]*ActiveWorldBoss)
+
+	if rawActive, ok := backupData["active_bosses"].([]any); ok {
+		for _, activeData := range rawActive {
+			active := DeserializeActiveWorldBoss(activeData.(map[string]any))
+
+			w.ActiveBosses[active.Boss] = active
+		}
 	}
 
 	return nil
 }
 
-func (w *World) RegisterParty(party party.Party) {
-	partyUuid := uuid.New()
+// Saves from before floor bosses had the floor after the starting one open
+func (w *World) unlockLegacyFloors() {
+	startFloor, exists := data.FloorMap[data.WorldConfig.StartFloor]
+
+	if !exists || startFloor.Next == "" {
+		return
+	}
+
+	w.UnlockedFloors = append(w.UnlockedFloors, startFloor.Next)
+}
+
+// Floor data is static, so unlocks have to be applied again after loading or reloading it
+func (w *World) ApplyFloorUnlocks() {
+	for _, floor := range w.UnlockedFloors {
+		data.FloorMap.UnlockFloor(floor)
+	}
+}
+
+func (w *World) HandleFloorBoss(location *types.Location, killedMobs []string, winners []*player.Player) {
+	if location == nil {
+		return
+	}
+
+	floor := data.FloorMap.FindFloor(func(l types.Location) bool { return l.Name == location.Name })
+
+	if floor == nil || floor.Boss == "" || floor.Next == "" || !slices.Contains(killedMobs, floor.Boss) {
+		return
+	}
+
+	nextFloor := data.FloorMap[floor.Next]
+
+	if data.WorldConfig.FloorUnlockPerPlayer {
+		for _, pl := range winners {
+			if nextFloor.Unlocked || !pl.UnlockFloor(nextFloor.Name) {
+				continue
+			}
 
-	for _, member := range party.Players {
-		w.Players[member.PlayerUuid].Meta.Party = &player.PartialParty{
-			UUID: partyUuid, Role: member.Role, MembersCount: len(party.Players),
+			w.SendMessage(
+				data.Config.LogChannelID,
+				discord.MessageCreate{
+					Embeds: []discord.Embed{{
+						Title: "Nowe piętro!",
+						Description: fmt.Sprintf(
+							"%s pokonuje strażnika piętra %s i odblokowuje piętro %s (nowy limit poziomu: %d)",
+							pl.GetName(), floor.Name, nextFloor.Name, pl.GetLevelCap(),
+						),
+					}},
+				},
+				false,
+			)
 		}
+
+		return
+	}
+
+	if nextFloor.Unlocked || slices.Contains(w.UnlockedFloors, nextFloor.Name) {
+		return
+	}
+
+	w.UnlockedFloors = append(w.UnlockedFloors, nextFloor.Name)
+	w.ApplyFloorUnlocks()
+
+	names := make([]string, 0)
+
+	for _, pl := range winners {
+		names = append(names, pl.GetName())
 	}
 
+	w.SendMessage(
+		data.Config.LogChannelID,
+		discord.MessageCreate{
+			Embeds: []discord.Embed{{
+				Title: "Nowe piętro!",
+				Description: fmt.Sprintf(
+					"%s pokonuje strażnika piętra %s, piętro %s zostaje odblokowane dla wszystkich (nowy limit poziomu: %d)",
+					strings.Join(names, ", "), floor.Name, nextFloor.Name, (data.FloorMap.GetUnlockedFloorCount()*5)-1,
+				),
+			}},
+		},
+		false,
+	)
+}
+
+func (w *World) RegisterParty(party party.Party) {
+	partyUuid := uuid.New()
+
 	w.Parties[partyUuid] = &party
+
+	w.SyncParty(partyUuid)
+}
+
+// Copies roles and member count into every member, has to be called after each party change
+func (w *World) SyncParty(partyUuid uuid.UUID) {
+	partyObj, exists := w.Parties[partyUuid]
+
+	if !exists {
+		return
+	}
+
+	roles := make([]party.PartyRole, 0)
+
+	for _, member := range partyObj.Players {
+		roles = append(roles, member.Role)
+	}
+
+	for _, member := range partyObj.Players {
+		if pl, ok := w.Players[member.PlayerUuid]; ok {
+			pl.Meta.Party = &player.PartialParty{
+				UUID: partyUuid, Role: member.Role, MembersCount: len(partyObj.Players), Roles: roles,
+			}
+		}
+	}
 }
@@ -3,8 +3,11 @@ package party
 import "github.com/google/uuid"
 
 type Party struct {
-	Players []*PartyEntry
-	Leader  uuid.UUID
+	Players  []*PartyEntry
+	Leader   uuid.UUID
+	LootMode LootMode
+	//Index in Players of the next round robin receiver
+	LootCursor int
 }
 
 type PartyEntry struct {
@@ -12,23 +15,45 @@ type PartyEntry struct {
 	Role       PartyRole
 }
 
-type PartyRole int
+// Role id from party config, None when member has no role
+type PartyRole string
 
 const (
-	DPS PartyRole = iota
-	Support
-	Tank
-	None
+	DPS     PartyRole = "DPS"
+	Support PartyRole = "Support"
+	Tank    PartyRole = "Tank"
+	None    PartyRole = ""
 )
 
+// Roles used to be saved as numbers before they were moved to party config
+var legacyRoles = []PartyRole{DPS, Support, Tank, None}
+
+type LootMode int
+
+const (
+	LootEven LootMode = iota
+	LootDamage
+	LootRoundRobin
+	LootNeedGreed
+)
+
+var LootModeToString = map[LootMode]string{
+	LootEven:       "Po równo",
+	LootDamage:     "Według obrażeń",
+	LootRoundRobin: "Po kolei",
+	LootNeedGreed:  "Potrzeba/chęć",
+}
+
 func (p *Party) Serialize() map[string]any {
 	members := make([]map[string]any, 0)
 
 	for _, player := range p.Players {
-		members = append(members, map[string]any{"player": player.PlayerUuid.String(), "role": player.Role})
+		members = append(members, map[string]any{"player": player.PlayerUuid.String(), "role": string(player.Role)})
 	}
 
-	return map[string]any{"players": members, "leader": p.Leader.String()}
+	return map[string]any{
+		"players": members, "leader": p.Leader.String(), "loot_mode": p.LootMode, "loot_cursor": p.LootCursor,
+	}
 }
 
 func Deserialize(data map[string]any) *Party {
@@ -40,11 +65,30 @@ func Deserialize(data map[string]any) *Party {
 
 		plr := player.(map[string]any)
 
+		role := None
+
+		switch rawRole := plr["role"].(type) {
+		case string:
+			role = PartyRole(rawRole)
+		case float64:
+			if int(rawRole) >= 0 && int(rawRole) < len(legacyRoles) {
+				role = legacyRoles[int(rawRole)]
+			}
+		}
+
 		party.Players = append(party.Players, &PartyEntry{
 			PlayerUuid: uuid.MustParse(plr["player"].(string)),
-			Role:       PartyRole(plr["role"].(float64)),
+			Role:       role,
 		})
 	}
 
+	if lootMode, ok := data["loot_mode"].(float64); ok {
+		party.LootMode = LootMode(lootMode)
+	}
+
+	if lootCursor, ok := data["loot_cursor"].(float64); ok {
+		party.LootCursor = int(lootCursor)
+	}
+
 	return party
 }
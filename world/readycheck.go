@@ -0,0 +1,228 @@
+package world
+
+import (
+	"errors"
+	"fmt"
+	"sao/battle/mobs"
+	"sao/player"
+	"sao/types"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/google/uuid"
+)
+
+// Party members confirming they join a fight started by one of them
+type ReadyCheck struct {
+	Initiator uuid.UUID
+	Location  *types.Location
+	ThreadId  string
+	Enemies   []*mobs.MobEntity
+	Pending   []uuid.UUID
+	Ready     []uuid.UUID
+	//Reason for every member that won't join the fight
+	Excluded map[uuid.UUID]string
+}
+
+const ReadyCheckTime = 30 * time.Second
+
+// Returns reason why member can't join a fight in given location, empty if they can
+func PartyFightExclusion(member *player.Player, location *types.Location) string {
+	if member.GetCurrentHP() <= 0 {
+		return "nie żyje"
+	}
+
+	if member.Meta.FightInstance != nil {
+		return "walczy w innej walce"
+	}
+
+	memberLocation := member.GetLocation()
+
+	if memberLocation == nil {
+		return "jest w podróży"
+	}
+
+	if location != nil && memberLocation.Name != location.Name {
+		return "jest w innej lokacji (" + memberLocation.Name + ")"
+	}
+
+	return ""
+}
+
+func (w *World) StartReadyCheck(pUuid uuid.UUID, location *types.Location, threadId string, enemies []*mobs.MobEntity) {
+	initiator := w.Players[pUuid]
+	partyUuid := initiator.Meta.Party.UUID
+
+	check := &ReadyCheck{
+		Initiator: pUuid,
+		Location:  location,
+		ThreadId:  threadId,
+		Enemies:   enemies,
+		Pending:   make([]uuid.UUID, 0),
+		Ready:     []uuid.UUID{pUuid},
+		Excluded:  make(map[uuid.UUID]string),
+	}
+
+	mentions := make([]string, 0)
+
+	for _, member := range w.Parties[partyUuid].Players {
+		if member.PlayerUuid == pUuid {
+			continue
+		}
+
+		memberObj, exists := w.Players[member.PlayerUuid]
+
+		if !exists {
+			continue
+		}
+
+		if reason := PartyFightExclusion(memberObj, location); reason != "" {
+			check.Excluded[member.PlayerUuid] = reason
+			continue
+		}
+
+		check.Pending = append(check.Pending, member.PlayerUuid)
+		mentions = append(mentions, fmt.Sprintf("<@%s>", memberObj.Meta.UserID))
+	}
+
+	if len(check.Pending) == 0 {
+		w.StartPartyFight(check)
+		return
+	}
+
+	w.ReadyChecks[partyUuid] = check
+
+	w.SendMessage(
+		check.GetChannelId(),
+		discord.NewMessageCreateBuilder().
+			SetContent(strings.Join(mentions, ", ")).
+			AddEmbeds(discord.NewEmbedBuilder().
+				SetTitle("Gotowi do walki?").
+				SetDescriptionf(
+					"%s rozpoczyna walkę. Potwierdź gotowość w ciągu %d sekund.",
+					initiator.GetName(), int(ReadyCheckTime.Seconds()),
+				).
+				Build(),
+			).
+			AddActionRow(
+				discord.NewSuccessButton("Gotowy", "ready/yes|"+partyUuid.String()),
+				discord.NewDangerButton("Nie dołączam", "ready/no|"+partyUuid.String()),
+			).
+			Build(),
+		false,
+	)
+
+	time.AfterFunc(ReadyCheckTime, func() {
+		w.Mutex.Lock()
+		defer w.Mutex.Unlock()
+
+		w.FinishReadyCheck(partyUuid, check)
+	})
+}
+
+func (rc *ReadyCheck) GetChannelId() string {
+	if rc.ThreadId != "" || rc.Location == nil {
+		return rc.ThreadId
+	}
+
+	return rc.Location.CID
+}
+
+func (w *World) AnswerReadyCheck(partyUuid uuid.UUID, playerUuid uuid.UUID, ready bool) error {
+	check, exists := w.ReadyChecks[partyUuid]
+
+	if !exists {
+		return errors.New("CHECK_NOT_FOUND")
+	}
+
+	idx := slices.Index(check.Pending, playerUuid)
+
+	if idx == -1 {
+		return errors.New("NOT_PENDING")
+	}
+
+	check.Pending = slices.Delete(check.Pending, idx, idx+1)
+
+	if ready {
+		check.Ready = append(check.Ready, playerUuid)
+	} else {
+		check.Excluded[playerUuid] = "nie dołącza"
+	}
+
+	if len(check.Pending) == 0 {
+		w.FinishReadyCheck(partyUuid, check)
+	}
+
+	return nil
+}
+
+// Check is passed so a stale timer doesn't finish a newer check of the same party
+func (w *World) FinishReadyCheck(partyUuid uuid.UUID, check *ReadyCheck) {
+	if w.ReadyChecks[partyUuid] != check {
+		return
+	}
+
+	delete(w.ReadyChecks, partyUuid)
+
+	for _, playerUuid := range check.Pending {
+		check.Excluded[playerUuid] = "nie potwierdził gotowości"
+	}
+
+	check.Pending = nil
+
+	w.StartPartyFight(check)
+}
+
+// Members are validated again, things could have changed during the ready check
+func (w *World) StartPartyFight(check *ReadyCheck) {
+	members := make([]uuid.UUID, 0)
+
+	for _, playerUuid := range check.Ready {
+		memberObj, exists := w.Players[playerUuid]
+
+		if !exists {
+			continue
+		}
+
+		if reason := PartyFightExclusion(memberObj, check.Location); reason != "" {
+			check.Excluded[playerUuid] = reason
+			continue
+		}
+
+		members = append(members, playerUuid)
+	}
+
+	if !slices.Contains(members, check.Initiator) {
+		w.SendMessage(
+			check.GetChannelId(),
+			discord.MessageCreate{Content: "Walka anulowana, rozpoczynający nie może już walczyć"},
+			false,
+		)
+
+		return
+	}
+
+	if len(check.Excluded) > 0 {
+		excludedText := ""
+
+		for playerUuid, reason := range check.Excluded {
+			if memberObj, exists := w.Players[playerUuid]; exists {
+				excludedText += fmt.Sprintf("%s (<@%s>) - %s\n", memberObj.GetName(), memberObj.Meta.UserID, reason)
+			}
+		}
+
+		w.SendMessage(
+			check.GetChannelId(),
+			discord.MessageCreate{Embeds: []discord.Embed{discord.NewEmbedBuilder().
+				SetTitle("Poza walką").
+				SetDescription(strings.TrimSuffix(excludedText, "\n")).
+				Build(),
+			}},
+			false,
+		)
+	}
+
+	w.StartFight(members, check.Location, check.ThreadId, check.Enemies)
+}
@@ -0,0 +1,419 @@
+package world
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sao/battle"
+	"sao/data"
+	"sao/types"
+	"sao/world/tournament"
+	"strings"
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/google/uuid"
+)
+
+// Tournament announcements and check ins go there
+const TournamentChannelID = "1225150345009827841"
+
+func TournamentDescription(tournamentObj *tournament.Tournament) string {
+	description := fmt.Sprintf("Zapisy na turniej `%v` otwarte!\nFormat: %v", tournamentObj.Name, TournamentFormatText(tournamentObj))
+
+	if tournamentObj.StartTime != nil {
+		description += fmt.Sprintf("\nStart: <t:%v:R>", tournamentObj.StartTime.Unix())
+	}
+
+	return description
+}
+
+// Players playing for the participant, all team members in team tournaments
+func (w *World) tournamentMembers(tournamentObj *tournament.Tournament, participant uuid.UUID) []uuid.UUID {
+	if team, exists := tournamentObj.Teams[participant]; exists {
+		return team.Members
+	}
+
+	return []uuid.UUID{participant}
+}
+
+func (w *World) notifyParticipant(tournamentObj *tournament.Tournament, participant uuid.UUID, content discord.MessageCreate) {
+	for _, playerUuid := range w.tournamentMembers(tournamentObj, participant) {
+		if pl, exists := w.Players[playerUuid]; exists {
+			w.SendMessage(pl.Meta.UserID, content, true)
+		}
+	}
+}
+
+// Opens check in and starts scheduled tournaments, forfeits matches past their deadline
+func (w *World) TickTournaments() {
+	for tUuid, tournamentObj := range w.Tournaments {
+		switch tournamentObj.State {
+		case tournament.Waiting:
+			if tournamentObj.StartTime == nil {
+				continue
+			}
+
+			checkInStart := tournamentObj.StartTime.Add(-time.Duration(data.TournamentConfig.CheckInTime) * time.Minute)
+
+			if !tournamentObj.CheckInOpen && time.Now().After(checkInStart) {
+				w.OpenCheckIn(tUuid)
+			}
+
+			if time.Now().After(*tournamentObj.StartTime) {
+				if err := w.StartTournament(tUuid); err != nil {
+					reason := "Nie udało się rozpocząć turnieju"
+
+					if err.Error() == "not enough players" {
+						reason = "Za mało uczestników"
+					}
+
+					w.CancelTournament(tUuid, reason)
+				}
+			}
+		case tournament.Running:
+			match := tournamentObj.GetRunningMatch()
+
+			if match != nil && match.Deadline != nil && time.Now().After(*match.Deadline) {
+				w.ForfeitMatch(tUuid)
+			}
+		}
+	}
+}
+
+func (w *World) OpenCheckIn(tUuid uuid.UUID) {
+	tournamentObj := w.Tournaments[tUuid]
+
+	if tournamentObj == nil || tournamentObj.CheckInOpen {
+		return
+	}
+
+	tournamentObj.CheckInOpen = true
+
+	//Participants always get the full window to check in
+	checkInEnd := time.Now().Add(time.Duration(data.TournamentConfig.CheckInTime) * time.Minute)
+
+	if tournamentObj.StartTime.Before(checkInEnd) {
+		tournamentObj.StartTime = &checkInEnd
+	}
+
+	checkInText := fmt.Sprintf(
+		"Turniej `%v` rozpoczyna się <t:%v:R>. Uczestnicy, którzy nie potwierdzą udziału, zostaną usunięci z turnieju.",
+		tournamentObj.Name, tournamentObj.StartTime.Unix(),
+	)
+
+	checkInButton := discord.NewPrimaryButton("Potwierdzam udział", "t/checkin/"+tUuid.String())
+
+	w.SendMessage(
+		TournamentChannelID,
+		discord.NewMessageCreateBuilder().
+			AddEmbeds(discord.NewEmbedBuilder().SetTitle("Potwierdzanie udziału").SetDescription(checkInText).Build()).
+			AddActionRow(checkInButton).
+			Build(),
+		false,
+	)
+
+	for _, participant := range tournamentObj.Participants {
+		w.notifyParticipant(
+			tournamentObj,
+			participant,
+			discord.NewMessageCreateBuilder().SetContent(checkInText).AddActionRow(checkInButton).Build(),
+		)
+	}
+}
+
+func (w *World) CheckInTournament(tUuid uuid.UUID, playerUuid uuid.UUID) error {
+	tournamentObj := w.Tournaments[tUuid]
+
+	if tournamentObj == nil {
+		return errors.New("TOURNAMENT_NOT_FOUND")
+	}
+
+	_, err := tournamentObj.CheckIn(playerUuid)
+
+	return err
+}
+
+// Drops participants who didn't check in, called right before the draw
+func (w *World) dropNoShows(tournamentObj *tournament.Tournament) {
+	dropped := tournamentObj.DropNoShows()
+
+	if len(dropped) == 0 {
+		return
+	}
+
+	names := make([]string, 0)
+
+	for _, participant := range dropped {
+		names = append(names, w.tournamentPlayerName(tournamentObj, participant))
+
+		w.notifyParticipant(
+			tournamentObj,
+			participant,
+			discord.NewMessageCreateBuilder().
+				SetContentf("Nie potwierdzono udziału w turnieju `%v`, zapis został anulowany", tournamentObj.Name).
+				Build(),
+		)
+	}
+
+	w.SendMessage(
+		TournamentChannelID,
+		discord.NewMessageCreateBuilder().
+			SetContentf("Z turnieju `%v` usunięto nieobecnych: %v", tournamentObj.Name, strings.Join(names, ", ")).
+			Build(),
+		false,
+	)
+}
+
+func (w *World) CancelTournament(tUuid uuid.UUID, reason string) {
+	tournamentObj := w.Tournaments[tUuid]
+
+	if tournamentObj == nil {
+		return
+	}
+
+	delete(w.Tournaments, tUuid)
+
+	w.RefundBets(func(bet *TournamentBet) bool {
+		return bet.Tournament == tUuid
+	}, fmt.Sprintf("turniej `%v` został odwołany", tournamentObj.Name))
+
+	for _, participant := range tournamentObj.Participants {
+		w.notifyParticipant(
+			tournamentObj,
+			participant,
+			discord.NewMessageCreateBuilder().
+				SetContentf("Turniej `%v` został odwołany: %v", tournamentObj.Name, reason).
+				Build(),
+		)
+	}
+
+	w.SendMessage(
+		TournamentChannelID,
+		discord.NewMessageCreateBuilder().
+			SetContentf("Turniej `%v` został odwołany: %v", tournamentObj.Name, reason).
+			Build(),
+		false,
+	)
+}
+
+// Participants of the next match are told to get ready
+func (w *World) RemindNextMatch(tUuid uuid.UUID) {
+	tournamentObj := w.Tournaments[tUuid]
+
+	if tournamentObj == nil {
+		return
+	}
+
+	match := tournamentObj.GetNextMatch()
+
+	if match == nil {
+		return
+	}
+
+	for idx, participant := range match.Players {
+		opponent := match.Players[1-idx]
+
+		w.notifyParticipant(
+			tournamentObj,
+			participant,
+			discord.NewMessageCreateBuilder().
+				SetContentf(
+					"Twój następny mecz w turnieju `%v` (przeciwnik: %v) zaczyna się zaraz po obecnym, bądź gotowy!",
+					tournamentObj.Name, w.tournamentPlayerName(tournamentObj, opponent),
+				).
+				Build(),
+		)
+	}
+}
+
+// Fights aren't persisted, the running match is replayed on the next clock tick
+func (w *World) ResumeTournament(tUuid uuid.UUID) {
+	tournamentObj := w.Tournaments[tUuid]
+
+	if tournamentObj == nil || tournamentObj.State != tournament.Running {
+		return
+	}
+
+	if tournamentObj.Channel == "" {
+		tournamentObj.Channel = TournamentChannelID
+	}
+
+	tournamentObj.ExternalChannel = make(chan tournament.TournamentEventData)
+
+	if match := tournamentObj.GetRunningMatch(); match != nil {
+		now := time.Now()
+		match.Deadline = &now
+	}
+
+	go w.ListenForTournament(tUuid)
+}
+
+// Side the fight is waiting on forfeits, without a pending turn the side with less health left loses
+func (w *World) ForfeitMatch(tUuid uuid.UUID) {
+	tournamentObj := w.Tournaments[tUuid]
+
+	if tournamentObj == nil {
+		return
+	}
+
+	match := tournamentObj.GetRunningMatch()
+
+	if match == nil {
+		return
+	}
+
+	match.Deadline = nil
+
+	var fight *battle.Fight
+
+	for _, fightObj := range w.Fights {
+		if fightObj.Meta != nil && fightObj.Meta.Tournament != nil && fightObj.Meta.Tournament.Tournament == tUuid {
+			fight = fightObj
+		}
+	}
+
+	//Fight was lost with a restart, match is played again
+	if fight == nil {
+		stage := tournamentObj.GetCurrentStage()
+
+		for matchIdx, stageMatch := range stage.Matches {
+			if stageMatch != match {
+				continue
+			}
+
+			match.State = tournament.BeforeMatch
+
+			w.SendMessage(
+				tournamentObj.Channel,
+				discord.NewMessageCreateBuilder().
+					SetContentf("Mecz #%v został przerwany, rozpoczyna się od nowa", matchIdx+1).
+					Build(),
+				false,
+			)
+
+			w.StartMatch(tUuid, matchIdx)
+		}
+
+		return
+	}
+
+	loserSide := 1
+
+	if entry, waiting := fight.Entities[fight.AwaitingAction]; waiting {
+		loserSide = entry.Side
+	} else if sideHealth(fight, 0) < sideHealth(fight, 1) {
+		loserSide = 0
+	}
+
+	w.SendMessage(
+		tournamentObj.Channel,
+		discord.NewMessageCreateBuilder().
+			SetContentf(
+				"Czas na mecz minął! %v przegrywa walkowerem",
+				w.tournamentPlayerName(tournamentObj, fight.Meta.Tournament.Sides[loserSide]),
+			).
+			Build(),
+		false,
+	)
+
+	fight.Forfeit(loserSide)
+}
+
+// Health left as a fraction of max health
+func sideHealth(fight *battle.Fight, side int) float64 {
+	current, total := 0, 0
+
+	for _, entity := range fight.FromSide(side) {
+		current += entity.GetCurrentHP()
+		total += entity.GetStat(types.STAT_HP)
+	}
+
+	if total == 0 {
+		return 0
+	}
+
+	return float64(current) / float64(total)
+}
+
+// Participant and team member names saved with tournament history
+func (w *World) tournamentNames(tournamentObj *tournament.Tournament) map[uuid.UUID]string {
+	names := make(map[uuid.UUID]string)
+
+	for _, participant := range tournamentObj.Participants {
+		names[participant] = w.tournamentPlayerName(tournamentObj, participant)
+
+		if team, exists := tournamentObj.Teams[participant]; exists {
+			for _, member := range team.Members {
+				names[member] = w.tournamentPlayerName(tournamentObj, member)
+			}
+		}
+	}
+
+	return names
+}
+
+func TournamentPrizeText(prize types.TournamentPrize) string {
+	rewardTexts := make([]string, 0)
+
+	for _, reward := range prize.Rewards {
+		switch reward.Type {
+		case types.LOOT_EXP:
+			rewardTexts = append(rewardTexts, fmt.Sprintf("%v exp", reward.Count))
+		case types.LOOT_GOLD:
+			rewardTexts = append(rewardTexts, fmt.Sprintf("%v złota", reward.Count))
+		case types.LOOT_ITEM:
+			rewardTexts = append(rewardTexts, fmt.Sprintf("%vx %v", reward.Count, data.Items[uuid.MustParse(reward.Item)].Name))
+		}
+	}
+
+	if prize.Title != "" {
+		rewardTexts = append(rewardTexts, fmt.Sprintf("tytuł \"%v\"", prize.Title))
+	}
+
+	return strings.Join(rewardTexts, ", ")
+}
+
+// Prizes go to every participant on a rewarded place, whole prize to each team member
+func (w *World) AwardTournamentPrizes(tournamentObj *tournament.Tournament) string {
+	pool := data.TournamentConfig.GetPrizePool(tournamentObj.PrizePool)
+
+	if pool == nil {
+		return ""
+	}
+
+	prizeText := ""
+
+	for _, standing := range tournamentObj.GetStandings() {
+		for _, prize := range pool.Prizes {
+			if prize.Place != standing.Place {
+				continue
+			}
+
+			for _, member := range w.tournamentMembers(tournamentObj, standing.Player) {
+				if pl, exists := w.Players[member]; exists {
+					pl.ReceiveTournamentPrize(prize)
+				}
+			}
+
+			prizeText += fmt.Sprintf(
+				"%v. %v - %v\n", standing.Place, w.tournamentPlayerName(tournamentObj, standing.Player), TournamentPrizeText(prize),
+			)
+		}
+	}
+
+	return strings.TrimSuffix(prizeText, "\n")
+}
+
+// Returns nil when bracket couldn't be drawn
+func (w *World) BracketFile(tournamentObj *tournament.Tournament) *discord.File {
+	rawImage, err := tournamentObj.RenderBracket(func(participant uuid.UUID) string {
+		return w.tournamentPlayerName(tournamentObj, participant)
+	})
+
+	if err != nil {
+		return nil
+	}
+
+	return discord.NewFile("drabinka.png", "Drabinka turnieju "+tournamentObj.Name, bytes.NewReader(rawImage))
+}
@@ -0,0 +1,317 @@
+package tournament
+
+import "github.com/google/uuid"
+
+func newMatch(players ...uuid.UUID) *TournamentMatch {
+	if len(players) == 1 {
+		return &TournamentMatch{Players: players, Winner: &players[0], State: FinishedMatch}
+	}
+
+	return &TournamentMatch{Players: players, State: BeforeMatch}
+}
+
+// Pairs players in order, with an odd count the player picked by byeIdx gets a bye
+func pairInOrder(players []uuid.UUID, byeIdx func([]uuid.UUID) int) []*TournamentMatch {
+	matches := make([]*TournamentMatch, 0)
+
+	if len(players)%2 != 0 {
+		idx := byeIdx(players)
+
+		matches = append(matches, newMatch(players[idx]))
+
+		players = append(append(make([]uuid.UUID, 0), players[:idx]...), players[idx+1:]...)
+	}
+
+	for i := 0; i+1 < len(players); i += 2 {
+		matches = append(matches, newMatch(players[i], players[i+1]))
+	}
+
+	return matches
+}
+
+// First round fills the bracket up to a power of two with byes for the top seeds,
+// spread so that two byes never meet in the second round unless there are more byes than real matches
+func (t *Tournament) singleEliminationMatches() []*TournamentMatch {
+	stage := t.GetCurrentStage()
+
+	if stage == nil {
+		bracketSize := 1
+
+		for bracketSize < len(t.Participants) {
+			bracketSize *= 2
+		}
+
+		slots := bracketSize / 2
+		byes := bracketSize - len(t.Participants)
+
+		slotOrder := make([]int, 0)
+
+		for i := 0; i < slots; i += 2 {
+			slotOrder = append(slotOrder, i)
+		}
+
+		for i := 1; i < slots; i += 2 {
+			slotOrder = append(slotOrder, i)
+		}
+
+		matches := make([]*TournamentMatch, slots)
+		next := 0
+
+		for _, slot := range slotOrder[:byes] {
+			matches[slot] = newMatch(t.Participants[next])
+			next++
+		}
+
+		for _, slot := range slotOrder[byes:] {
+			matches[slot] = newMatch(t.Participants[next], t.Participants[next+1])
+			next += 2
+		}
+
+		return matches
+	}
+
+	if len(stage.Matches) == 1 {
+		return nil
+	}
+
+	winners := make([]uuid.UUID, 0)
+
+	for _, match := range stage.Matches {
+		winners = append(winners, *match.Winner)
+	}
+
+	return pairInOrder(winners, func(players []uuid.UUID) int { return len(players) - 1 })
+}
+
+// Winners and losers brackets play in the same stages, players drop down after the first loss
+// and are out after the second one. Grand final is replayed when the losers bracket player wins it
+func (t *Tournament) doubleEliminationMatches() []*TournamentMatch {
+	records := t.getRecords()
+
+	upper := make([]uuid.UUID, 0)
+	lower := make([]uuid.UUID, 0)
+
+	for _, participant := range t.Participants {
+		switch records[participant].Losses {
+		case 0:
+			upper = append(upper, participant)
+		case 1:
+			lower = append(lower, participant)
+		}
+	}
+
+	if len(upper)+len(lower) <= 1 {
+		return nil
+	}
+
+	if len(upper) == 1 && len(lower) == 1 {
+		return []*TournamentMatch{newMatch(upper[0], lower[0])}
+	}
+
+	//Player with the fewest byes gets one, lower seeds first
+	byeIdx := func(players []uuid.UUID) int {
+		idx := len(players) - 1
+
+		for i := len(players) - 1; i >= 0; i-- {
+			if records[players[i]].Byes < records[players[idx]].Byes {
+				idx = i
+			}
+		}
+
+		return idx
+	}
+
+	matches := make([]*TournamentMatch, 0)
+
+	for _, bracket := range [][]uuid.UUID{upper, lower} {
+		//Single player waits for the other bracket
+		if len(bracket) > 1 {
+			matches = append(matches, pairInOrder(bracket, byeIdx)...)
+		}
+	}
+
+	return matches
+}
+
+// Circle method, with an odd count one player sits out each round
+func (t *Tournament) roundRobinMatches() []*TournamentMatch {
+	players := make([]*uuid.UUID, 0)
+
+	for idx := range t.Participants {
+		players = append(players, &t.Participants[idx])
+	}
+
+	if len(players)%2 != 0 {
+		players = append(players, nil)
+	}
+
+	round := len(t.Stages)
+
+	if round >= len(players)-1 {
+		return nil
+	}
+
+	rotated := []*uuid.UUID{players[0]}
+
+	for i := 0; i < len(players)-1; i++ {
+		rotated = append(rotated, players[1+(i+round)%(len(players)-1)])
+	}
+
+	matches := make([]*TournamentMatch, 0)
+
+	for i := 0; i < len(rotated)/2; i++ {
+		first, second := rotated[i], rotated[len(rotated)-1-i]
+
+		if first == nil || second == nil {
+			continue
+		}
+
+		matches = append(matches, newMatch(*first, *second))
+	}
+
+	return matches
+}
+
+func SwissRounds(participants int) int {
+	rounds := 0
+
+	for (1 << rounds) < participants {
+		rounds++
+	}
+
+	return max(rounds, 1)
+}
+
+// Players are paired with others on the same score, rematches are avoided when possible.
+// With an odd count the lowest ranked player without a bye gets one, worth a win
+func (t *Tournament) swissMatches() []*TournamentMatch {
+	if len(t.Stages) >= SwissRounds(len(t.Participants)) {
+		return nil
+	}
+
+	records := t.getRecords()
+
+	ranked := make([]uuid.UUID, 0)
+
+	for _, standing := range t.GetStandings() {
+		ranked = append(ranked, standing.Player)
+	}
+
+	matches := make([]*TournamentMatch, 0)
+
+	if len(ranked)%2 != 0 {
+		byeIdx := len(ranked) - 1
+
+		for i := len(ranked) - 1; i >= 0; i-- {
+			if records[ranked[i]].Byes == 0 {
+				byeIdx = i
+				break
+			}
+		}
+
+		matches = append(matches, newMatch(ranked[byeIdx]))
+
+		ranked = append(append(make([]uuid.UUID, 0), ranked[:byeIdx]...), ranked[byeIdx+1:]...)
+	}
+
+	played := func(a, b uuid.UUID) bool {
+		for _, opponent := range records[a].Opponents {
+			if opponent == b {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	pairs := pairWithoutRematches(ranked, played)
+
+	if pairs == nil {
+		return append(matches, pairInOrder(ranked, func(players []uuid.UUID) int { return len(players) - 1 })...)
+	}
+
+	for _, pair := range pairs {
+		matches = append(matches, newMatch(pair[0], pair[1]))
+	}
+
+	return matches
+}
+
+// Backtracking, highest ranked player takes the closest opponent they haven't met yet
+func pairWithoutRematches(players []uuid.UUID, played func(a, b uuid.UUID) bool) [][2]uuid.UUID {
+	if len(players) == 0 {
+		return make([][2]uuid.UUID, 0)
+	}
+
+	for i := 1; i < len(players); i++ {
+		if played(players[0], players[i]) {
+			continue
+		}
+
+		rest := make([]uuid.UUID, 0, len(players)-2)
+		rest = append(rest, players[1:i]...)
+		rest = append(rest, players[i+1:]...)
+
+		if pairs := pairWithoutRematches(rest, played); pairs != nil {
+			return append([][2]uuid.UUID{{players[0], players[i]}}, pairs...)
+		}
+	}
+
+	return nil
+}
+
+type participantRecord struct {
+	Wins      int
+	Losses    int
+	Byes      int
+	Opponents []uuid.UUID
+	Beaten    []uuid.UUID
+	//Stage of the last loss, -1 without losses
+	LastLoss int
+	Seed     int
+}
+
+func (t *Tournament) getRecords() map[uuid.UUID]*participantRecord {
+	records := make(map[uuid.UUID]*participantRecord)
+
+	for idx, participant := range t.Participants {
+		records[participant] = &participantRecord{
+			Opponents: make([]uuid.UUID, 0),
+			Beaten:    make([]uuid.UUID, 0),
+			LastLoss:  -1,
+			Seed:      idx,
+		}
+	}
+
+	for stageIdx, stage := range t.Stages {
+		for _, match := range stage.Matches {
+			if match.State != FinishedMatch || match.Winner == nil {
+				continue
+			}
+
+			if match.IsBye() {
+				if record, exists := records[match.Players[0]]; exists {
+					record.Byes++
+				}
+
+				continue
+			}
+
+			winner, loser := *match.Winner, *match.GetLoser()
+
+			if record, exists := records[winner]; exists {
+				record.Wins++
+				record.Opponents = append(record.Opponents, loser)
+				record.Beaten = append(record.Beaten, loser)
+			}
+
+			if record, exists := records[loser]; exists {
+				record.Losses++
+				record.Opponents = append(record.Opponents, winner)
+				record.LastLoss = stageIdx
+			}
+		}
+	}
+
+	return records
+}
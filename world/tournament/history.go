@@ -0,0 +1,159 @@
+package tournament
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Finished tournament kept for records, names are saved since characters can be gone later
+type HistoryEntry struct {
+	Tournament *Tournament
+	FinishedAt time.Time
+	Names      map[uuid.UUID]string
+}
+
+type PlayerRecord struct {
+	Played      int
+	Won         int
+	Podiums     int
+	MatchWins   int
+	MatchLosses int
+	//0 when player never played
+	BestPlace int
+}
+
+type FameEntry struct {
+	Player uuid.UUID
+	Name   string
+	Wins   int
+}
+
+func NewHistoryEntry(t *Tournament, names map[uuid.UUID]string) *HistoryEntry {
+	return &HistoryEntry{Tournament: t, FinishedAt: time.Now(), Names: names}
+}
+
+func (h *HistoryEntry) GetName(participant uuid.UUID) string {
+	if name, exists := h.Names[participant]; exists {
+		return name
+	}
+
+	return "Nieznany gracz"
+}
+
+// Standing of the player, or their team in team tournaments. Returns nil when player didn't play
+func (h *HistoryEntry) GetStanding(playerUuid uuid.UUID) *Standing {
+	participant := h.Tournament.GetParticipant(playerUuid)
+
+	if participant == uuid.Nil {
+		return nil
+	}
+
+	for _, standing := range h.Tournament.GetStandings() {
+		if standing.Player == participant {
+			return &standing
+		}
+	}
+
+	return nil
+}
+
+func GetPlayerRecord(history []*HistoryEntry, playerUuid uuid.UUID) PlayerRecord {
+	record := PlayerRecord{}
+
+	for _, entry := range history {
+		standing := entry.GetStanding(playerUuid)
+
+		if standing == nil {
+			continue
+		}
+
+		record.Played++
+		record.MatchWins += standing.Wins
+		record.MatchLosses += standing.Losses
+
+		if standing.Place == 1 {
+			record.Won++
+		}
+
+		if standing.Place <= 3 {
+			record.Podiums++
+		}
+
+		if record.BestPlace == 0 || standing.Place < record.BestPlace {
+			record.BestPlace = standing.Place
+		}
+	}
+
+	return record
+}
+
+// Players sorted by tournament wins, every member of a winning team counts
+func GetHallOfFame(history []*HistoryEntry, limit int) []FameEntry {
+	fame := make([]FameEntry, 0)
+
+	for _, entry := range history {
+		winner := entry.Tournament.GetWinner()
+
+		if winner == nil {
+			continue
+		}
+
+		members := []uuid.UUID{*winner}
+
+		if team, exists := entry.Tournament.Teams[*winner]; exists {
+			members = team.Members
+		}
+
+		for _, member := range members {
+			idx := slices.IndexFunc(fame, func(fameEntry FameEntry) bool { return fameEntry.Player == member })
+
+			if idx == -1 {
+				fame = append(fame, FameEntry{Player: member})
+				idx = len(fame) - 1
+			}
+
+			fame[idx].Wins++
+			fame[idx].Name = entry.GetName(member)
+		}
+	}
+
+	slices.SortStableFunc(fame, func(a, b FameEntry) int { return b.Wins - a.Wins })
+
+	if len(fame) > limit {
+		fame = fame[:limit]
+	}
+
+	return fame
+}
+
+func (h *HistoryEntry) Serialize() map[string]interface{} {
+	names := make(map[string]string)
+
+	for participant, name := range h.Names {
+		names[participant.String()] = name
+	}
+
+	return map[string]interface{}{
+		"tournament":  h.Tournament.Serialize(),
+		"finished_at": h.FinishedAt.Unix(),
+		"names":       names,
+	}
+}
+
+func DeserializeHistoryEntry(rawData map[string]interface{}) *HistoryEntry {
+	t := Deserialize(rawData["tournament"].(map[string]interface{}))
+
+	entry := &HistoryEntry{
+		Tournament: &t,
+		FinishedAt: time.Unix(int64(rawData["finished_at"].(float64)), 0),
+		Names:      make(map[uuid.UUID]string),
+	}
+
+	for participant, name := range rawData["names"].(map[string]interface{}) {
+		entry.Names[uuid.MustParse(participant)] = name.(string)
+	}
+
+	return entry
+}
@@ -1,6 +1,9 @@
 package tournament
 
 import (
+	"errors"
+	"time"
+
 	"github.com/google/uuid"
 )
 
@@ -8,9 +11,21 @@ type Tournament struct {
 	Uuid uuid.UUID
 	Name string
 	//-1 for unlimited
-	MaxPlayers      int
-	Channel         string
-	Participants    []uuid.UUID
+	MaxPlayers int
+	Channel    string
+	Format     TournamentFormat
+	//Players per side in team tournaments, 0 for single player ones
+	TeamSize int
+	//Keyed by participant uuid, empty for single player tournaments
+	Teams        map[uuid.UUID]*TournamentTeam
+	Participants []uuid.UUID
+	//Nil for tournaments started by hand
+	StartTime   *time.Time
+	CheckInOpen bool
+	//Participants confirmed during check in
+	CheckedIn map[uuid.UUID]bool
+	//Name of prize pool from tournament config, empty for no prizes
+	PrizePool       string
 	State           TournamentState
 	Stages          []*TournamentStage
 	ExternalChannel chan TournamentEventData
@@ -21,10 +36,13 @@ type TournamentStage struct {
 	IDX     int
 }
 
+// Matches with a single player are byes, they are finished from the start
 type TournamentMatch struct {
 	Players []uuid.UUID
 	Winner  *uuid.UUID
 	State   MatchState
+	//Set when the match starts, match is forfeited after it
+	Deadline *time.Time
 }
 
 type MatchState int
@@ -43,34 +61,114 @@ const (
 	Finished
 )
 
-func (t *Tournament) NextStage() {
-	if t.State != Running {
-		return
+type TournamentFormat int
+
+const (
+	SingleElimination TournamentFormat = iota
+	DoubleElimination
+	RoundRobin
+	Swiss
+)
+
+var FormatToString = map[TournamentFormat]string{
+	SingleElimination: "Pojedyncza eliminacja",
+	DoubleElimination: "Podwójna eliminacja",
+	RoundRobin:        "Każdy z każdym",
+	Swiss:             "System szwajcarski",
+}
+
+func (tm *TournamentMatch) IsBye() bool {
+	return len(tm.Players) == 1
+}
+
+// Returns nil for byes and unfinished matches
+func (tm *TournamentMatch) GetLoser() *uuid.UUID {
+	if tm.IsBye() || tm.Winner == nil {
+		return nil
 	}
 
-	stage := t.Stages[len(t.Stages)-1]
+	if tm.Players[0] == *tm.Winner {
+		return &tm.Players[1]
+	}
 
-	for _, match := range stage.Matches {
+	return &tm.Players[0]
+}
+
+func (ts *TournamentStage) IsFinished() bool {
+	for _, match := range ts.Matches {
 		if match.State != FinishedMatch {
-			return
+			return false
 		}
 	}
 
-	if len(stage.Matches) == 1 {
+	return true
+}
+
+// Participants are seeded in their current order, shuffle them beforehand for a random draw
+func (t *Tournament) Start() error {
+	if t.State != Waiting {
+		return errors.New("TOURNAMENT_RUNNING")
+	}
+
+	if len(t.Participants) < 2 {
+		return errors.New("NOT_ENOUGH_PLAYERS")
+	}
+
+	t.State = Running
+	t.Stages = make([]*TournamentStage, 0)
+
+	t.NextStage()
+
+	return nil
+}
+
+func (t *Tournament) GetCurrentStage() *TournamentStage {
+	if len(t.Stages) == 0 {
+		return nil
+	}
+
+	return t.Stages[len(t.Stages)-1]
+}
+
+// Creates stages until one of them has matches to play, finishes the tournament when format runs out of them
+func (t *Tournament) NextStage() {
+	if t.State != Running {
+		return
+	}
+
+	if stage := t.GetCurrentStage(); stage != nil && !stage.IsFinished() {
 		return
 	}
 
-	newMatches := make([]*TournamentMatch, 0)
+	for {
+		matches := t.nextMatches()
 
-	for i := 0; i < len(stage.Matches); i += 2 {
-		newMatches = append(newMatches, &TournamentMatch{
-			Players: []uuid.UUID{*stage.Matches[i].Winner, *stage.Matches[i+1].Winner},
-			Winner:  nil,
-			State:   BeforeMatch,
-		})
+		if len(matches) == 0 {
+			t.State = Finished
+			return
+		}
+
+		stage := &TournamentStage{Matches: matches, IDX: len(t.Stages)}
+
+		t.Stages = append(t.Stages, stage)
+
+		if !stage.IsFinished() {
+			return
+		}
 	}
+}
 
-	t.Stages = append(t.Stages, &TournamentStage{Matches: newMatches})
+func (t *Tournament) nextMatches() []*TournamentMatch {
+	switch t.Format {
+	case DoubleElimination:
+		return t.doubleEliminationMatches()
+	case RoundRobin:
+		return t.roundRobinMatches()
+	case Swiss:
+		return t.swissMatches()
+	default:
+		return t.singleEliminationMatches()
+	}
 }
 
 func (t *Tournament) FinishMatch(winner uuid.UUID) {
@@ -78,9 +176,17 @@ func (t *Tournament) FinishMatch(winner uuid.UUID) {
 		return
 	}
 
-	stage := t.Stages[len(t.Stages)-1]
+	stage := t.GetCurrentStage()
+
+	if stage == nil {
+		return
+	}
 
 	for _, match := range stage.Matches {
+		if match.State == FinishedMatch || match.IsBye() {
+			continue
+		}
+
 		for _, player := range match.Players {
 			if player == winner {
 				match.Winner = &winner
@@ -89,10 +195,21 @@ func (t *Tournament) FinishMatch(winner uuid.UUID) {
 			}
 		}
 	}
+}
 
-	if len(stage.Matches) == 1 {
-		t.State = Finished
+// Returns nil until the tournament is finished
+func (t *Tournament) GetWinner() *uuid.UUID {
+	if t.State != Finished {
+		return nil
 	}
+
+	standings := t.GetStandings()
+
+	if len(standings) == 0 {
+		return nil
+	}
+
+	return &standings[0].Player
 }
 
 func (t *Tournament) Serialize() map[string]interface{} {
@@ -103,23 +220,82 @@ func (t *Tournament) Serialize() map[string]interface{} {
 		tStages = append(tStages, stage.Serialize())
 	}
 
+	teams := make(map[string]interface{})
+
+	for teamUuid, team := range t.Teams {
+		teams[teamUuid.String()] = team.Serialize()
+	}
+
+	var startTime interface{} = nil
+
+	if t.StartTime != nil {
+		startTime = t.StartTime.Unix()
+	}
+
+	checkedIn := make([]string, 0)
+
+	for participant := range t.CheckedIn {
+		checkedIn = append(checkedIn, participant.String())
+	}
+
 	return map[string]interface{}{
-		"uuid":         t.Uuid,
-		"name":         t.Name,
-		"max_players":  t.MaxPlayers,
-		"participants": t.Participants,
-		"state":        t.State,
-		"stages":       tStages,
+		"channel":       t.Channel,
+		"prize_pool":    t.PrizePool,
+		"start_time":    startTime,
+		"check_in_open": t.CheckInOpen,
+		"checked_in":    checkedIn,
+		"team_size":     t.TeamSize,
+		"teams":         teams,
+		"uuid":          t.Uuid,
+		"name":          t.Name,
+		"max_players":   t.MaxPlayers,
+		"format":        t.Format,
+		"participants":  t.Participants,
+		"state":         t.State,
+		"stages":        tStages,
 	}
 }
 
 func (ts *TournamentStage) Serialize() map[string]interface{} {
+	matches := make([]map[string]interface{}, 0)
+
+	for _, match := range ts.Matches {
+		matches = append(matches, match.Serialize())
+	}
+
 	return map[string]interface{}{
-		"matches": ts.Matches,
+		"matches": matches,
 		"idx":     ts.IDX,
 	}
 }
 
+func (tm *TournamentMatch) Serialize() map[string]interface{} {
+	var winner interface{} = nil
+
+	if tm.Winner != nil {
+		winner = tm.Winner.String()
+	}
+
+	players := make([]string, 0)
+
+	for _, player := range tm.Players {
+		players = append(players, player.String())
+	}
+
+	var deadline interface{} = nil
+
+	if tm.Deadline != nil {
+		deadline = tm.Deadline.Unix()
+	}
+
+	return map[string]interface{}{
+		"players":  players,
+		"winner":   winner,
+		"state":    tm.State,
+		"deadline": deadline,
+	}
+}
+
 func Deserialize(rawData map[string]interface{}) Tournament {
 	rawParticipants := rawData["participants"].([]interface{})
 
@@ -137,6 +313,38 @@ func Deserialize(rawData map[string]interface{}) Tournament {
 		State:        TournamentState(rawData["state"].(float64)),
 	}
 
+	if format, ok := rawData["format"].(float64); ok {
+		t.Format = TournamentFormat(format)
+	}
+
+	t.Teams = make(map[uuid.UUID]*TournamentTeam)
+
+	if teamSize, ok := rawData["team_size"].(float64); ok {
+		t.TeamSize = int(teamSize)
+	}
+
+	if rawTeams, ok := rawData["teams"].(map[string]interface{}); ok {
+		for teamUuid, team := range rawTeams {
+			t.Teams[uuid.MustParse(teamUuid)] = DeserializeTeam(team.(map[string]interface{}))
+		}
+	}
+
+	if startTime, ok := rawData["start_time"].(float64); ok {
+		parsedTime := time.Unix(int64(startTime), 0)
+		t.StartTime = &parsedTime
+	}
+
+	t.Channel, _ = rawData["channel"].(string)
+	t.PrizePool, _ = rawData["prize_pool"].(string)
+	t.CheckInOpen, _ = rawData["check_in_open"].(bool)
+	t.CheckedIn = make(map[uuid.UUID]bool)
+
+	if rawCheckedIn, ok := rawData["checked_in"].([]interface{}); ok {
+		for _, participant := range rawCheckedIn {
+			t.CheckedIn[uuid.MustParse(participant.(string))] = true
+		}
+	}
+
 	tStages := rawData["stages"].([]interface{})
 
 	for _, stage := range tStages {
@@ -148,17 +356,19 @@ func Deserialize(rawData map[string]interface{}) Tournament {
 
 func DeserializeStage(rawData map[string]interface{}) *TournamentStage {
 	ts := TournamentStage{
-		IDX:     rawData["idx"].(int),
+		IDX:     int(rawData["idx"].(float64)),
 		Matches: make([]*TournamentMatch, 0),
 	}
 
-	matches := rawData["matches"].([]map[string]interface{})
+	matches := rawData["matches"].([]interface{})
+
+	for _, rawMatch := range matches {
+		match := rawMatch.(map[string]interface{})
 
-	for _, match := range matches {
 		parsedPlayers := make([]uuid.UUID, 0)
 
-		for _, player := range match["players"].([]string) {
-			parsedPlayers = append(parsedPlayers, uuid.MustParse(player))
+		for _, player := range match["players"].([]interface{}) {
+			parsedPlayers = append(parsedPlayers, uuid.MustParse(player.(string)))
 		}
 
 		var winner *uuid.UUID
@@ -170,10 +380,18 @@ func DeserializeStage(rawData map[string]interface{}) *TournamentStage {
 			winner = nil
 		}
 
+		var deadline *time.Time
+
+		if rawDeadline, ok := match["deadline"].(float64); ok {
+			parsedTime := time.Unix(int64(rawDeadline), 0)
+			deadline = &parsedTime
+		}
+
 		ts.Matches = append(ts.Matches, &TournamentMatch{
-			Players: parsedPlayers,
-			Winner:  winner,
-			State:   match["state"].(MatchState),
+			Players:  parsedPlayers,
+			Winner:   winner,
+			State:    MatchState(match["state"].(float64)),
+			Deadline: deadline,
 		})
 	}
 
@@ -0,0 +1,586 @@
+package tournament
+
+import (
+	"bytes"
+	"encoding/json"
+	"image/png"
+	"math/rand"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func newTestTournament(format TournamentFormat, count int) *Tournament {
+	participants := make([]uuid.UUID, 0)
+
+	for range count {
+		participants = append(participants, uuid.New())
+	}
+
+	return &Tournament{
+		Uuid:         uuid.New(),
+		Name:         "test",
+		MaxPlayers:   -1,
+		Format:       format,
+		Participants: participants,
+	}
+}
+
+// Plays matches in stage order picking random winners, fails when the tournament doesn't end
+func playTournament(t *testing.T, tournament *Tournament, rng *rand.Rand) {
+	t.Helper()
+
+	if err := tournament.Start(); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+
+	for played := 0; tournament.State == Running; played++ {
+		if played > 10*len(tournament.Participants)*len(tournament.Participants) {
+			t.Fatalf("tournament didn't finish after %d matches", played)
+		}
+
+		stage := tournament.GetCurrentStage()
+
+		var next *TournamentMatch
+
+		for _, match := range stage.Matches {
+			if match.State == BeforeMatch {
+				next = match
+				break
+			}
+		}
+
+		if next == nil {
+			t.Fatalf("stage %d has no matches to play", stage.IDX)
+		}
+
+		next.State = RunningMatch
+
+		tournament.FinishMatch(next.Players[rng.Intn(2)])
+		tournament.NextStage()
+	}
+}
+
+func checkStages(t *testing.T, tournament *Tournament) {
+	t.Helper()
+
+	isParticipant := make(map[uuid.UUID]bool)
+
+	for _, participant := range tournament.Participants {
+		isParticipant[participant] = true
+	}
+
+	for stageIdx, stage := range tournament.Stages {
+		if stage.IDX != stageIdx {
+			t.Errorf("stage %d has idx %d", stageIdx, stage.IDX)
+		}
+
+		if !stage.IsFinished() {
+			t.Errorf("stage %d isn't finished", stageIdx)
+		}
+
+		seen := make(map[uuid.UUID]bool)
+
+		for _, match := range stage.Matches {
+			if len(match.Players) < 1 || len(match.Players) > 2 {
+				t.Fatalf("stage %d has a match with %d players", stageIdx, len(match.Players))
+			}
+
+			if match.Winner == nil {
+				t.Fatalf("stage %d has a match without winner", stageIdx)
+			}
+
+			for _, player := range match.Players {
+				if !isParticipant[player] {
+					t.Errorf("stage %d has a player outside of participants", stageIdx)
+				}
+
+				if seen[player] {
+					t.Errorf("player plays twice in stage %d", stageIdx)
+				}
+
+				seen[player] = true
+			}
+		}
+	}
+}
+
+func checkStandings(t *testing.T, tournament *Tournament) []Standing {
+	t.Helper()
+
+	standings := tournament.GetStandings()
+
+	if len(standings) != len(tournament.Participants) {
+		t.Fatalf("got %d standings for %d participants", len(standings), len(tournament.Participants))
+	}
+
+	if standings[0].Place != 1 {
+		t.Errorf("first standing has place %d", standings[0].Place)
+	}
+
+	for idx := 1; idx < len(standings); idx++ {
+		if standings[idx].Place < standings[idx-1].Place {
+			t.Errorf("places aren't sorted at %d", idx)
+		}
+
+		if standings[idx].Place != standings[idx-1].Place && standings[idx].Place != idx+1 {
+			t.Errorf("place %d after shared places at index %d", standings[idx].Place, idx)
+		}
+	}
+
+	winner := tournament.GetWinner()
+
+	if winner == nil || *winner != standings[0].Player {
+		t.Fatalf("winner doesn't match first place")
+	}
+
+	//Champion can't share the first place
+	if standings[1].Place == 1 && (tournament.Format == SingleElimination || tournament.Format == DoubleElimination) {
+		t.Errorf("elimination champion shares first place")
+	}
+
+	return standings
+}
+
+func countMatches(tournament *Tournament) (matches int, byes int) {
+	for _, stage := range tournament.Stages {
+		for _, match := range stage.Matches {
+			if match.IsBye() {
+				byes++
+			} else {
+				matches++
+			}
+		}
+	}
+
+	return matches, byes
+}
+
+func TestSingleElimination(t *testing.T) {
+	for count := 2; count <= 64; count++ {
+		tournament := newTestTournament(SingleElimination, count)
+
+		playTournament(t, tournament, rand.New(rand.NewSource(int64(count))))
+		checkStages(t, tournament)
+		standings := checkStandings(t, tournament)
+
+		bracketSize := 1
+
+		for bracketSize < count {
+			bracketSize *= 2
+		}
+
+		matches, byes := countMatches(tournament)
+
+		if matches != count-1 {
+			t.Errorf("%d players: %d matches, expected %d", count, matches, count-1)
+		}
+
+		if byes != bracketSize-count {
+			t.Errorf("%d players: %d byes, expected %d", count, byes, bracketSize-count)
+		}
+
+		for stageIdx, stage := range tournament.Stages[1:] {
+			for _, match := range stage.Matches {
+				if match.IsBye() {
+					t.Errorf("%d players: bye in stage %d", count, stageIdx+1)
+				}
+			}
+		}
+
+		if standings[0].Losses != 0 {
+			t.Errorf("%d players: champion lost a match", count)
+		}
+
+		for _, standing := range standings[1:] {
+			if standing.Losses != 1 {
+				t.Errorf("%d players: eliminated player with %d losses", count, standing.Losses)
+			}
+		}
+	}
+}
+
+func TestDoubleElimination(t *testing.T) {
+	for count := 2; count <= 64; count++ {
+		tournament := newTestTournament(DoubleElimination, count)
+
+		playTournament(t, tournament, rand.New(rand.NewSource(int64(count))))
+		checkStages(t, tournament)
+		standings := checkStandings(t, tournament)
+
+		matches, _ := countMatches(tournament)
+
+		if matches != 2*count-2 && matches != 2*count-1 {
+			t.Errorf("%d players: %d matches, expected %d or %d", count, matches, 2*count-2, 2*count-1)
+		}
+
+		if standings[0].Losses > 1 {
+			t.Errorf("%d players: champion lost %d matches", count, standings[0].Losses)
+		}
+
+		for _, standing := range standings[1:] {
+			if standing.Losses != 2 {
+				t.Errorf("%d players: eliminated player with %d losses", count, standing.Losses)
+			}
+		}
+	}
+}
+
+func TestRoundRobin(t *testing.T) {
+	for count := 2; count <= 64; count++ {
+		tournament := newTestTournament(RoundRobin, count)
+
+		playTournament(t, tournament, rand.New(rand.NewSource(int64(count))))
+		checkStages(t, tournament)
+		standings := checkStandings(t, tournament)
+
+		expectedRounds := count - 1
+
+		if count%2 != 0 {
+			expectedRounds = count
+		}
+
+		if len(tournament.Stages) != expectedRounds {
+			t.Errorf("%d players: %d rounds, expected %d", count, len(tournament.Stages), expectedRounds)
+		}
+
+		met := make(map[[2]uuid.UUID]int)
+
+		for _, stage := range tournament.Stages {
+			for _, match := range stage.Matches {
+				if match.IsBye() {
+					t.Fatalf("%d players: bye in round robin", count)
+				}
+
+				a, b := match.Players[0], match.Players[1]
+
+				if a.String() > b.String() {
+					a, b = b, a
+				}
+
+				met[[2]uuid.UUID{a, b}]++
+			}
+		}
+
+		if len(met) != count*(count-1)/2 {
+			t.Errorf("%d players: %d pairs met, expected %d", count, len(met), count*(count-1)/2)
+		}
+
+		for _, times := range met {
+			if times != 1 {
+				t.Errorf("%d players: pair met %d times", count, times)
+			}
+		}
+
+		for idx := 1; idx < len(standings); idx++ {
+			if standings[idx].Points > standings[idx-1].Points {
+				t.Errorf("%d players: standings not sorted by points", count)
+			}
+		}
+	}
+}
+
+func TestSwiss(t *testing.T) {
+	for count := 2; count <= 64; count++ {
+		tournament := newTestTournament(Swiss, count)
+
+		playTournament(t, tournament, rand.New(rand.NewSource(int64(count))))
+		checkStages(t, tournament)
+		standings := checkStandings(t, tournament)
+
+		if len(tournament.Stages) != SwissRounds(count) {
+			t.Errorf("%d players: %d rounds, expected %d", count, len(tournament.Stages), SwissRounds(count))
+		}
+
+		met := make(map[[2]uuid.UUID]bool)
+
+		for stageIdx, stage := range tournament.Stages {
+			inStage := 0
+
+			for _, match := range stage.Matches {
+				inStage += len(match.Players)
+
+				if match.IsBye() {
+					continue
+				}
+
+				a, b := match.Players[0], match.Players[1]
+
+				if a.String() > b.String() {
+					a, b = b, a
+				}
+
+				if met[[2]uuid.UUID{a, b}] {
+					t.Errorf("%d players: rematch in round %d", count, stageIdx+1)
+				}
+
+				met[[2]uuid.UUID{a, b}] = true
+			}
+
+			if inStage != count {
+				t.Errorf("%d players: %d players in round %d", count, inStage, stageIdx+1)
+			}
+		}
+
+		for _, standing := range standings {
+			if standing.Byes > 1 {
+				t.Errorf("%d players: player got %d byes", count, standing.Byes)
+			}
+
+			if standing.Points != standing.Wins+standing.Byes {
+				t.Errorf("%d players: points don't include byes", count)
+			}
+		}
+
+		for idx := 1; idx < len(standings); idx++ {
+			prev, curr := standings[idx-1], standings[idx]
+
+			if curr.Points > prev.Points || (curr.Points == prev.Points && curr.Buchholz > prev.Buchholz) {
+				t.Errorf("%d players: standings not sorted by points and Buchholz", count)
+			}
+		}
+	}
+}
+
+func TestSerializeRoundTrip(t *testing.T) {
+	for _, format := range []TournamentFormat{SingleElimination, DoubleElimination, RoundRobin, Swiss} {
+		tournament := newTestTournament(format, 7)
+
+		playTournament(t, tournament, rand.New(rand.NewSource(7)))
+
+		rawData, err := json.Marshal(tournament.Serialize())
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		decoded := make(map[string]interface{})
+
+		if err := json.Unmarshal(rawData, &decoded); err != nil {
+			t.Fatal(err)
+		}
+
+		loaded := Deserialize(decoded)
+
+		if loaded.Format != format || loaded.State != Finished || len(loaded.Stages) != len(tournament.Stages) {
+			t.Fatalf("%s: tournament changed after loading", FormatToString[format])
+		}
+
+		if *loaded.GetWinner() != *tournament.GetWinner() {
+			t.Errorf("%s: winner changed after loading", FormatToString[format])
+		}
+	}
+}
+
+func TestStartRequiresTwoPlayers(t *testing.T) {
+	tournament := newTestTournament(SingleElimination, 1)
+
+	if err := tournament.Start(); err == nil {
+		t.Errorf("tournament started with a single player")
+	}
+}
+
+func TestTeams(t *testing.T) {
+	tournament := &Tournament{
+		Uuid:         uuid.New(),
+		Name:         "test",
+		MaxPlayers:   -1,
+		TeamSize:     2,
+		Teams:        make(map[uuid.UUID]*TournamentTeam),
+		Participants: make([]uuid.UUID, 0),
+	}
+
+	first := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+	second := []uuid.UUID{uuid.New(), uuid.New()}
+
+	firstTeam, err := tournament.RegisterTeam("first", uuid.New(), first)
+
+	if err != nil {
+		t.Fatalf("register: %v", err)
+	}
+
+	if _, err := tournament.RegisterTeam("small", uuid.New(), second[:1]); err == nil {
+		t.Errorf("registered a team smaller than team size")
+	}
+
+	if _, err := tournament.RegisterTeam("duplicate", uuid.New(), []uuid.UUID{first[0], uuid.New()}); err == nil {
+		t.Errorf("registered a player in two teams")
+	}
+
+	secondTeam, err := tournament.RegisterTeam("second", uuid.New(), second)
+
+	if err != nil {
+		t.Fatalf("register: %v", err)
+	}
+
+	if tournament.GetTeamOf(second[1]) != secondTeam {
+		t.Errorf("player not found in their team")
+	}
+
+	lineup := tournament.GetLineup(firstTeam, func(playerUuid uuid.UUID) bool { return true })
+
+	if len(lineup) != 2 || lineup[0] != first[0] || lineup[1] != first[1] {
+		t.Errorf("starters not picked in order")
+	}
+
+	lineup = tournament.GetLineup(firstTeam, func(playerUuid uuid.UUID) bool { return playerUuid != first[1] })
+
+	if len(lineup) != 2 || lineup[1] != first[2] {
+		t.Errorf("substitute didn't replace unavailable starter")
+	}
+
+	playTournament(t, tournament, rand.New(rand.NewSource(2)))
+
+	if winner := tournament.GetWinner(); winner == nil || (*winner != firstTeam && *winner != secondTeam) {
+		t.Errorf("winner isn't a team")
+	}
+
+	rawData, err := json.Marshal(tournament.Serialize())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded := make(map[string]interface{})
+
+	if err := json.Unmarshal(rawData, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := Deserialize(decoded)
+
+	if loaded.TeamSize != 2 || len(loaded.Teams) != 2 || len(loaded.Teams[firstTeam].Members) != 3 {
+		t.Errorf("teams changed after loading")
+	}
+}
+
+func TestCheckIn(t *testing.T) {
+	tournament := newTestTournament(SingleElimination, 4)
+	tournament.CheckedIn = make(map[uuid.UUID]bool)
+
+	if _, err := tournament.CheckIn(tournament.Participants[0]); err == nil {
+		t.Errorf("checked in before check in opened")
+	}
+
+	if dropped := tournament.DropNoShows(); len(dropped) != 0 {
+		t.Errorf("dropped players without check in")
+	}
+
+	tournament.CheckInOpen = true
+
+	for _, participant := range tournament.Participants[:3] {
+		if _, err := tournament.CheckIn(participant); err != nil {
+			t.Fatalf("check in: %v", err)
+		}
+	}
+
+	if _, err := tournament.CheckIn(uuid.New()); err == nil {
+		t.Errorf("outsider checked in")
+	}
+
+	noShow := tournament.Participants[3]
+
+	if dropped := tournament.DropNoShows(); len(dropped) != 1 || dropped[0] != noShow {
+		t.Fatalf("wrong players dropped")
+	}
+
+	if tournament.GetParticipant(noShow) != uuid.Nil {
+		t.Errorf("dropped player is still a participant")
+	}
+
+	playTournament(t, tournament, rand.New(rand.NewSource(3)))
+}
+
+func TestHistory(t *testing.T) {
+	history := make([]*HistoryEntry, 0)
+
+	for seed := range 3 {
+		tournament := newTestTournament(SingleElimination, 4)
+
+		playTournament(t, tournament, rand.New(rand.NewSource(int64(seed))))
+
+		history = append(history, NewHistoryEntry(tournament, map[uuid.UUID]string{}))
+	}
+
+	winner := *history[0].Tournament.GetWinner()
+
+	record := GetPlayerRecord(history, winner)
+
+	if record.Played != 1 || record.Won != 1 || record.BestPlace != 1 || record.MatchWins != 2 || record.MatchLosses != 0 {
+		t.Errorf("wrong record for the winner: %+v", record)
+	}
+
+	if outsider := GetPlayerRecord(history, uuid.New()); outsider.Played != 0 || outsider.BestPlace != 0 {
+		t.Errorf("outsider has a record")
+	}
+
+	fame := GetHallOfFame(history, 2)
+
+	if len(fame) != 2 || fame[0].Wins != 1 {
+		t.Errorf("wrong hall of fame")
+	}
+
+	rawData, err := json.Marshal(history[0].Serialize())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded := make(map[string]interface{})
+
+	if err := json.Unmarshal(rawData, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	if loaded := DeserializeHistoryEntry(decoded); *loaded.Tournament.GetWinner() != winner {
+		t.Errorf("winner changed after loading history")
+	}
+}
+
+func TestRenderBracket(t *testing.T) {
+	for _, format := range []TournamentFormat{SingleElimination, DoubleElimination, RoundRobin, Swiss} {
+		tournament := newTestTournament(format, 13)
+
+		playTournament(t, tournament, rand.New(rand.NewSource(13)))
+
+		rawImage, err := tournament.RenderBracket(func(participant uuid.UUID) string { return "Gracz " + participant.String() })
+
+		if err != nil {
+			t.Fatalf("%s: %v", FormatToString[format], err)
+		}
+
+		img, err := png.Decode(bytes.NewReader(rawImage))
+
+		if err != nil {
+			t.Fatalf("%s: rendered image can't be decoded: %v", FormatToString[format], err)
+		}
+
+		columns := min(len(tournament.Stages), MaxRenderedStages)
+
+		if img.Bounds().Dx() != 2*imagePadding+columns*boxWidth+(columns-1)*columnGap {
+			t.Errorf("%s: image has %d px for %d stages", FormatToString[format], img.Bounds().Dx(), columns)
+		}
+	}
+}
+
+func TestMatchOdds(t *testing.T) {
+	if odds := MatchOdds(10, 10, 10, 5); odds != 190 {
+		t.Errorf("even match has odds %d", odds)
+	}
+
+	if MatchOdds(20, 10, 10, 5) >= MatchOdds(10, 20, 10, 5) {
+		t.Errorf("favourite pays more than underdog")
+	}
+
+	if odds := MatchOdds(10, 10, 10, 0); odds != 200 {
+		t.Errorf("even match without margin has odds %d", odds)
+	}
+
+	if odds := MatchOdds(100, 1, 10, 5); odds != 101 {
+		t.Errorf("odds %d below the minimum", odds)
+	}
+
+	if text := OddsToString(105); text != "x1.05" {
+		t.Errorf("odds shown as %s", text)
+	}
+}
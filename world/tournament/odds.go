@@ -0,0 +1,18 @@
+package tournament
+
+import (
+	"fmt"
+	"math"
+)
+
+// Payout in percent of the stake for a bet on the first side, odds never go below a 1% profit.
+// Level difference of levelScale makes the stronger side 10 times more likely to win
+func MatchOdds(pickLevel, otherLevel float64, levelScale int, margin int) int {
+	chance := 1 / (1 + math.Pow(10, (otherLevel-pickLevel)/float64(levelScale)))
+
+	return max(int(float64(100-margin)/chance), 101)
+}
+
+func OddsToString(odds int) string {
+	return fmt.Sprintf("x%d.%02d", odds/100, odds%100)
+}
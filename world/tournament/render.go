@@ -0,0 +1,145 @@
+package tournament
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Only the latest stages are drawn, long round robins would make huge images
+const MaxRenderedStages = 8
+
+const (
+	textScale     = 2
+	boxWidth      = 240
+	rowHeight     = 24
+	boxHeight     = 2 * rowHeight
+	boxGap        = 16
+	columnGap     = 48
+	imagePadding  = 16
+	headerHeight  = 32
+	borderWidth   = 2
+	maxNameLength = (boxWidth - 2*8) / (glyphWidth + 1) / textScale
+)
+
+var (
+	backgroundColor = color.RGBA{0x2b, 0x2d, 0x31, 0xff}
+	boxColor        = color.RGBA{0x38, 0x3a, 0x40, 0xff}
+	lineColor       = color.RGBA{0x4e, 0x50, 0x58, 0xff}
+	runningColor    = color.RGBA{0xfe, 0xe7, 0x5c, 0xff}
+	winnerColor     = color.RGBA{0x57, 0xf2, 0x87, 0xff}
+	loserColor      = color.RGBA{0x94, 0x9b, 0xa4, 0xff}
+	textColor       = color.RGBA{0xf2, 0xf3, 0xf5, 0xff}
+)
+
+// Draws stages as columns of matches, single elimination matches are placed between the ones feeding them
+func (t *Tournament) RenderBracket(names func(participant uuid.UUID) string) ([]byte, error) {
+	stages := t.Stages
+
+	if len(stages) > MaxRenderedStages {
+		stages = stages[len(stages)-MaxRenderedStages:]
+	}
+
+	centers := make([][]int, len(stages))
+	height := 0
+
+	for stageIdx, stage := range stages {
+		centers[stageIdx] = make([]int, len(stage.Matches))
+
+		for matchIdx := range stage.Matches {
+			center := imagePadding + headerHeight + matchIdx*(boxHeight+boxGap) + boxHeight/2
+
+			if t.Format == SingleElimination && stageIdx > 0 && 2*matchIdx+1 < len(centers[stageIdx-1]) {
+				center = (centers[stageIdx-1][2*matchIdx] + centers[stageIdx-1][2*matchIdx+1]) / 2
+			}
+
+			centers[stageIdx][matchIdx] = center
+			height = max(height, center+boxHeight/2+imagePadding)
+		}
+	}
+
+	width := 2*imagePadding + len(stages)*boxWidth + max(len(stages)-1, 0)*columnGap
+	height = max(height, 2*imagePadding+headerHeight)
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	fillRect(img, img.Bounds(), backgroundColor)
+
+	for stageIdx, stage := range stages {
+		x := imagePadding + stageIdx*(boxWidth+columnGap)
+
+		drawText(img, x, imagePadding, fmt.Sprintf("Runda %d", stage.IDX+1), textColor, textScale)
+
+		for matchIdx, match := range stage.Matches {
+			top := centers[stageIdx][matchIdx] - boxHeight/2
+
+			drawMatch(img, x, top, match, names)
+
+			if t.Format == SingleElimination && stageIdx > 0 && 2*matchIdx+1 < len(centers[stageIdx-1]) {
+				for _, feeder := range centers[stageIdx-1][2*matchIdx : 2*matchIdx+2] {
+					drawConnector(img, x-columnGap, feeder, x, centers[stageIdx][matchIdx])
+				}
+			}
+		}
+	}
+
+	var buffer bytes.Buffer
+
+	if err := png.Encode(&buffer, img); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+func drawMatch(img *image.RGBA, x, y int, match *TournamentMatch, names func(participant uuid.UUID) string) {
+	border := lineColor
+
+	if match.State == RunningMatch {
+		border = runningColor
+	}
+
+	fillRect(img, image.Rect(x, y, x+boxWidth, y+boxHeight), border)
+	fillRect(img, image.Rect(x+borderWidth, y+borderWidth, x+boxWidth-borderWidth, y+boxHeight-borderWidth), boxColor)
+	fillRect(img, image.Rect(x+borderWidth, y+rowHeight-1, x+boxWidth-borderWidth, y+rowHeight+1), border)
+
+	for idx := range 2 {
+		name := "Wolny los"
+		nameColor := loserColor
+
+		if idx < len(match.Players) {
+			name = names(match.Players[idx])
+			nameColor = textColor
+
+			if match.Winner != nil && !match.IsBye() {
+				nameColor = loserColor
+
+				if *match.Winner == match.Players[idx] {
+					nameColor = winnerColor
+				}
+			}
+		}
+
+		if len([]rune(name)) > maxNameLength {
+			name = strings.TrimSpace(string([]rune(name)[:maxNameLength-1])) + "."
+		}
+
+		textY := y + idx*rowHeight + (rowHeight-glyphHeight*textScale)/2
+
+		drawText(img, x+8, textY, name, nameColor, textScale)
+	}
+}
+
+// Line from the right side of a match to the left side of the one its winner goes to
+func drawConnector(img *image.RGBA, fromX, fromY, toX, toY int) {
+	middle := (fromX + toX) / 2
+
+	fillRect(img, image.Rect(fromX, fromY-1, middle+1, fromY+1), lineColor)
+	fillRect(img, image.Rect(middle-1, min(fromY, toY)-1, middle+1, max(fromY, toY)+1), lineColor)
+	fillRect(img, image.Rect(middle-1, toY-1, toX, toY+1), lineColor)
+}
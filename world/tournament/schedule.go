@@ -0,0 +1,97 @@
+package tournament
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+// Player's participant uuid, their team in team tournaments. Returns uuid.Nil for outsiders
+func (t *Tournament) GetParticipant(playerUuid uuid.UUID) uuid.UUID {
+	if t.IsTeamMode() {
+		return t.GetTeamOf(playerUuid)
+	}
+
+	if slices.Contains(t.Participants, playerUuid) {
+		return playerUuid
+	}
+
+	return uuid.Nil
+}
+
+// Any team member can check in for the whole team
+func (t *Tournament) CheckIn(playerUuid uuid.UUID) (uuid.UUID, error) {
+	if t.State != Waiting || !t.CheckInOpen {
+		return uuid.Nil, errors.New("CHECK_IN_CLOSED")
+	}
+
+	participant := t.GetParticipant(playerUuid)
+
+	if participant == uuid.Nil {
+		return uuid.Nil, errors.New("NOT_PARTICIPANT")
+	}
+
+	if t.CheckedIn[participant] {
+		return uuid.Nil, errors.New("ALREADY_CHECKED_IN")
+	}
+
+	t.CheckedIn[participant] = true
+
+	return participant, nil
+}
+
+// Removes participants that didn't check in, nothing is dropped when check in never opened
+func (t *Tournament) DropNoShows() []uuid.UUID {
+	dropped := make([]uuid.UUID, 0)
+
+	if !t.CheckInOpen {
+		return dropped
+	}
+
+	//Dropped teams are kept so they can still be named
+	t.Participants = slices.DeleteFunc(t.Participants, func(participant uuid.UUID) bool {
+		if t.CheckedIn[participant] {
+			return false
+		}
+
+		dropped = append(dropped, participant)
+
+		return true
+	})
+
+	return dropped
+}
+
+func (t *Tournament) GetRunningMatch() *TournamentMatch {
+	stage := t.GetCurrentStage()
+
+	if t.State != Running || stage == nil {
+		return nil
+	}
+
+	for _, match := range stage.Matches {
+		if match.State == RunningMatch {
+			return match
+		}
+	}
+
+	return nil
+}
+
+// First match waiting in the current stage
+func (t *Tournament) GetNextMatch() *TournamentMatch {
+	stage := t.GetCurrentStage()
+
+	if t.State != Running || stage == nil {
+		return nil
+	}
+
+	for _, match := range stage.Matches {
+		if match.State == BeforeMatch {
+			return match
+		}
+	}
+
+	return nil
+}
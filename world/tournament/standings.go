@@ -0,0 +1,131 @@
+package tournament
+
+import (
+	"slices"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+type Standing struct {
+	Player uuid.UUID
+	Place  int
+	Wins   int
+	Losses int
+	Byes   int
+	//Wins for round robin, wins and byes for Swiss, unused in elimination formats
+	Points int
+	//Still able to win an elimination tournament
+	Alive bool
+	//Round robin, wins against players on the same points
+	HeadToHead int
+	//Swiss, sum of opponents' points
+	Buchholz int
+	//Sum of points of beaten opponents
+	SonnebornBerger int
+	seed            int
+	//Stage of the last loss, later is better for eliminated players
+	lastLoss int
+}
+
+// Sorted from the first place, players that can't be separated by tie-breakers share the place
+func (t *Tournament) GetStandings() []Standing {
+	records := t.getRecords()
+	standings := make([]Standing, 0)
+
+	for _, participant := range t.Participants {
+		record := records[participant]
+
+		standing := Standing{
+			Player:   participant,
+			Wins:     record.Wins,
+			Losses:   record.Losses,
+			Byes:     record.Byes,
+			Points:   record.Wins,
+			seed:     record.Seed,
+			lastLoss: record.LastLoss,
+		}
+
+		switch t.Format {
+		case SingleElimination:
+			standing.Alive = record.Losses == 0
+		case DoubleElimination:
+			standing.Alive = record.Losses < 2
+		case Swiss:
+			standing.Points += record.Byes
+		}
+
+		standings = append(standings, standing)
+	}
+
+	points := make(map[uuid.UUID]int)
+
+	for _, standing := range standings {
+		points[standing.Player] = standing.Points
+	}
+
+	for idx := range standings {
+		record := records[standings[idx].Player]
+
+		for _, opponent := range record.Opponents {
+			standings[idx].Buchholz += points[opponent]
+		}
+
+		for _, beaten := range record.Beaten {
+			standings[idx].SonnebornBerger += points[beaten]
+
+			if t.Format == RoundRobin && points[beaten] == standings[idx].Points {
+				standings[idx].HeadToHead++
+			}
+		}
+	}
+
+	compare := t.compareStandings
+
+	sort.SliceStable(standings, func(i, j int) bool {
+		if res := compare(standings[i], standings[j]); res != 0 {
+			return res < 0
+		}
+
+		return standings[i].seed < standings[j].seed
+	})
+
+	for idx := range standings {
+		standings[idx].Place = idx + 1
+
+		if idx > 0 && compare(standings[idx-1], standings[idx]) == 0 {
+			standings[idx].Place = standings[idx-1].Place
+		}
+	}
+
+	return standings
+}
+
+// Negative when a is ranked higher, seed order isn't taken into account
+func (t *Tournament) compareStandings(a, b Standing) int {
+	keys := func(s Standing) []int {
+		switch t.Format {
+		case SingleElimination, DoubleElimination:
+			aliveKey := 0
+
+			if s.Alive {
+				aliveKey = 1
+			}
+
+			//Alive players are ranked by losses, eliminated ones by how long they lasted
+			lossKey := -s.Losses
+
+			if !s.Alive {
+				lossKey = s.lastLoss
+			}
+
+			return []int{aliveKey, lossKey, s.Wins}
+		case RoundRobin:
+			return []int{s.Points, s.HeadToHead, s.SonnebornBerger}
+		default:
+			return []int{s.Points, s.Buchholz, s.SonnebornBerger}
+		}
+	}
+
+	return -slices.Compare(keys(a), keys(b))
+}
@@ -0,0 +1,119 @@
+package tournament
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+// Team registered from a party, members past TeamSize are substitutes
+type TournamentTeam struct {
+	Name    string
+	Party   uuid.UUID
+	Members []uuid.UUID
+}
+
+func (t *Tournament) IsTeamMode() bool {
+	return t.TeamSize > 0
+}
+
+// Team uuid is used as the participant, so brackets work the same way as for single players
+func (t *Tournament) RegisterTeam(name string, partyUuid uuid.UUID, members []uuid.UUID) (uuid.UUID, error) {
+	if !t.IsTeamMode() {
+		return uuid.Nil, errors.New("NOT_TEAM_TOURNAMENT")
+	}
+
+	if t.State != Waiting {
+		return uuid.Nil, errors.New("TOURNAMENT_RUNNING")
+	}
+
+	if t.MaxPlayers != -1 && len(t.Participants) >= t.MaxPlayers {
+		return uuid.Nil, errors.New("TOURNAMENT_FULL")
+	}
+
+	if len(members) < t.TeamSize {
+		return uuid.Nil, errors.New("TEAM_TOO_SMALL")
+	}
+
+	for _, team := range t.Teams {
+		if team.Party == partyUuid {
+			return uuid.Nil, errors.New("ALREADY_REGISTERED")
+		}
+
+		for _, member := range members {
+			if slices.Contains(team.Members, member) {
+				return uuid.Nil, errors.New("ALREADY_REGISTERED")
+			}
+		}
+	}
+
+	teamUuid := uuid.New()
+
+	t.Teams[teamUuid] = &TournamentTeam{Name: name, Party: partyUuid, Members: slices.Clone(members)}
+	t.Participants = append(t.Participants, teamUuid)
+
+	return teamUuid, nil
+}
+
+// Returns uuid.Nil when player isn't in any team, teams dropped from participants are skipped
+func (t *Tournament) GetTeamOf(playerUuid uuid.UUID) uuid.UUID {
+	for _, participant := range t.Participants {
+		if team, exists := t.Teams[participant]; exists && slices.Contains(team.Members, playerUuid) {
+			return participant
+		}
+	}
+
+	return uuid.Nil
+}
+
+// Starters that can't play are replaced by substitutes in registration order
+func (t *Tournament) GetLineup(teamUuid uuid.UUID, available func(playerUuid uuid.UUID) bool) []uuid.UUID {
+	lineup := make([]uuid.UUID, 0)
+
+	team, exists := t.Teams[teamUuid]
+
+	if !exists {
+		return lineup
+	}
+
+	for _, member := range team.Members {
+		if len(lineup) >= t.TeamSize {
+			break
+		}
+
+		if available(member) {
+			lineup = append(lineup, member)
+		}
+	}
+
+	return lineup
+}
+
+func (tt *TournamentTeam) Serialize() map[string]interface{} {
+	members := make([]string, 0)
+
+	for _, member := range tt.Members {
+		members = append(members, member.String())
+	}
+
+	return map[string]interface{}{
+		"name":    tt.Name,
+		"party":   tt.Party.String(),
+		"members": members,
+	}
+}
+
+func DeserializeTeam(rawData map[string]interface{}) *TournamentTeam {
+	team := &TournamentTeam{
+		Name:    rawData["name"].(string),
+		Party:   uuid.MustParse(rawData["party"].(string)),
+		Members: make([]uuid.UUID, 0),
+	}
+
+	for _, member := range rawData["members"].([]interface{}) {
+		team.Members = append(team.Members, uuid.MustParse(member.(string)))
+	}
+
+	return team
+}